@@ -14,16 +14,24 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/NamiraNet/namira-core/internal/api"
-	"github.com/NamiraNet/namira-core/internal/core"
-	"github.com/NamiraNet/namira-core/internal/github"
-	"github.com/NamiraNet/namira-core/internal/logger"
-	"github.com/NamiraNet/namira-core/internal/notify"
-	workerpool "github.com/NamiraNet/namira-core/internal/worker"
-	"github.com/redis/go-redis/v9"
+	"github.com/NaMiraNet/namira-core/internal/api"
+	namiragrpc "github.com/NaMiraNet/namira-core/internal/api/grpc"
+	"github.com/NaMiraNet/namira-core/internal/config"
+	"github.com/NaMiraNet/namira-core/internal/core"
+	"github.com/NaMiraNet/namira-core/internal/core/checker"
+	"github.com/NaMiraNet/namira-core/internal/crypto"
+	"github.com/NaMiraNet/namira-core/internal/faultproxy"
+	"github.com/NaMiraNet/namira-core/internal/github"
+	"github.com/NaMiraNet/namira-core/internal/logger"
+	"github.com/NaMiraNet/namira-core/internal/metrics"
+	"github.com/NaMiraNet/namira-core/internal/notify"
+	"github.com/NaMiraNet/namira-core/internal/redisclient"
+	"github.com/NaMiraNet/namira-core/internal/tracing"
+	workerpool "github.com/NaMiraNet/namira-core/internal/worker"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
 )
 
 var apiCmd = &cobra.Command{
@@ -43,6 +51,18 @@ func runAPIServer(cmd *cobra.Command, args []string) {
 		_ = logger.Sync()
 	}()
 
+	tracingShutdown, err := tracing.Init(context.Background(), name, cfg.App.TracingEndpoint)
+	if err != nil {
+		logger.Fatal("Failed to initialize tracing", zap.Error(err))
+	}
+	defer func() {
+		if err := tracingShutdown(context.Background()); err != nil {
+			logger.Error("Failed to shut down tracing", zap.Error(err))
+		}
+	}()
+
+	metricsRegistry := metrics.NewRegistry()
+
 	// Use global config, but allow CLI flags to override
 	checkServer, checkP, err := net.SplitHostPort(cfg.App.CheckHost)
 	if err != nil {
@@ -50,46 +70,70 @@ func runAPIServer(cmd *cobra.Command, args []string) {
 	}
 	checkPort, _ := strconv.Atoi(checkP)
 
-	// Initialize Redis client
-	redisClient = redis.NewClient(&redis.Options{
-		Addr:     cfg.Redis.Addr,
-		Password: cfg.Redis.Password,
-		DB:       cfg.Redis.DB,
-	})
+	// Initialize Redis client (single instance, Sentinel, or Cluster, per cfg.Redis.Mode)
+	redisClient, err = redisclient.New(cfg.Redis)
+	if err != nil {
+		logger.Fatal("Failed to build Redis client", zap.Error(err))
+	}
 
 	ctx := context.Background()
 	if err := redisClient.Ping(ctx).Err(); err != nil {
 		logger.Fatal("Failed to connect to Redis", zap.Error(err))
 	}
-	logger.Info("Connected to Redis successfully", zap.String("addr", cfg.Redis.Addr))
+	logger.Info("Connected to Redis successfully", zap.String("mode", string(cfg.Redis.Mode)), zap.String("addr", cfg.Redis.Addr))
 
 	// Initialize GitHub updater
-	encryptionKey := []byte(cfg.App.EncryptionKey)
-	updater, err = github.NewUpdater(
-		logger,
-		cfg.Github.SSHKeyPath,
-		redisClient,
-		cfg.Github.Owner,
-		cfg.Github.Repo,
-		encryptionKey,
-	)
+	keyring, err := newKeyring(cfg.App)
+	if err != nil {
+		logger.Fatal("Failed to build encryption keyring:", zap.Error(err))
+	}
+
+	resultStore, err := github.NewResultStore(logger, cfg.Github, redisClient)
 	if err != nil {
-		logger.Fatal("Failed to create updater:", zap.Error(err))
+		logger.Fatal("Failed to create result store:", zap.Error(err))
 	}
 
+	updater = github.NewUpdater(logger, resultStore, redisClient, keyring, cfg.App.EncryptionEnvelopeMode, cfg.App.ResultsCompression, cfg.Github.FlushInterval)
+
 	if err := updater.HealthCheck(); err != nil {
-		logger.Fatal("GitHub SSH connectivity test failed:", zap.Error(err))
+		logger.Fatal("Result store connectivity test failed:", zap.Error(err))
+	}
+
+	if err := updater.Start(ctx); err != nil {
+		logger.Fatal("Failed to start updater", zap.Error(err))
+	}
+	defer updater.Stop()
+
+	logger.Info("Updater initialized successfully",
+		zap.String("storage_backend", cfg.Github.StorageBackend),
+		zap.String("repo", fmt.Sprintf("%s/%s", cfg.Github.Owner, cfg.Github.Repo)))
+
+	checkEndpoints, err := parseCheckEndpoints(cfg.App.CheckEndpoints)
+	if err != nil {
+		logger.Fatal("Failed to parse check endpoints", zap.Error(err))
 	}
 
-	logger.Info("GitHub updater initialized successfully",
-		zap.String("repo", fmt.Sprintf("%s/%s", cfg.Github.Owner, cfg.Github.Repo)),
-		zap.String("ssh_key", cfg.Github.SSHKeyPath))
+	// faultRegistry is always created so /debug/faults (when enabled) has
+	// something to mutate; with no rules installed it's a pass-through.
+	faultRegistry := faultproxy.NewRegistry()
+	faultDialer := faultproxy.NewDialer(faultRegistry, nil)
 
 	coreInstance := core.NewCore(core.CoreOpts{
-		CheckTimeout:       cfg.App.Timeout,
-		CheckServer:        checkServer,
-		CheckPort:          uint32(checkPort),
-		CheckMaxConcurrent: cfg.App.MaxConcurrent,
+		CheckTimeout:         cfg.App.Timeout,
+		CheckServer:          checkServer,
+		CheckPort:            uint32(checkPort),
+		CheckMaxConcurrent:   cfg.App.MaxConcurrent,
+		CheckEndpoints:       checkEndpoints,
+		Dialer:               faultDialer.DialContext,
+		GeoDBPath:            cfg.App.GeoIPDBPath,
+		GeoIPAccountID:       cfg.App.GeoIPAccountID,
+		GeoIPLicenseKey:      cfg.App.GeoIPLicenseKey,
+		GeoIPRefreshInterval: cfg.App.GeoIPRefreshInterval,
+		GeoFilter: checker.GeoFilter{
+			Allow: cfg.App.GeoAllowCountries,
+			Deny:  cfg.App.GeoDenyCountries,
+		},
+		Metrics: metricsRegistry,
 		RemarkTemplate: &core.RemarkTemplate{
 			OrgName:      "@NamiraNet",
 			Separator:    " | ",
@@ -174,6 +218,18 @@ func runAPIServer(cmd *cobra.Command, args []string) {
 			}
 		}()
 	}
+	var notifyRegistry *notify.NotifierRegistry
+	if cfg.App.NotifyConfigPath != "" {
+		notifyCfg, err := notify.LoadConfig(cfg.App.NotifyConfigPath)
+		if err != nil {
+			logger.Fatal("Failed to load notify config", zap.Error(err))
+		}
+		notifyRegistry, err = notify.Build(notifyCfg, logger)
+		if err != nil {
+			logger.Fatal("Failed to build notify registry", zap.Error(err))
+		}
+	}
+
 	// worker instace
 	worker := workerpool.NewWorkerPool(workerpool.WorkerPoolConfig{
 		WorkerCount:   cfg.Worker.Count,
@@ -198,7 +254,17 @@ func runAPIServer(cmd *cobra.Command, args []string) {
 		worker,
 		versionInfo,
 		cfg.Redis.ResultTTL,
-		cfg.App.RefreshInterval)
+		cfg.App.RefreshInterval,
+		cfg.Server.TrustedProxies,
+		api.RateLimitConfig{
+			RPS:     cfg.RateLimit.RPS,
+			Burst:   cfg.RateLimit.Burst,
+			LRUSize: cfg.RateLimit.LRUSize,
+		},
+		faultRegistry,
+		cfg.App.DebugFaultsEnabled,
+		metricsRegistry,
+		notifyRegistry)
 
 	serverAddr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
 	server := &http.Server{
@@ -220,6 +286,9 @@ func runAPIServer(cmd *cobra.Command, args []string) {
 		}
 	}()
 
+	grpcServer := startCommanderServer(cfg.Server.GRPCPort, coreInstance, logger)
+	metricsServer := startMetricsServer(cfg.Server.MetricsPort, metricsRegistry, logger)
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -232,6 +301,68 @@ func runAPIServer(cmd *cobra.Command, args []string) {
 	if err := server.Shutdown(ctx); err != nil {
 		logger.Fatal("Server forced to shutdown", zap.Error(err))
 	}
+
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(ctx); err != nil {
+			logger.Error("Metrics server forced to shutdown", zap.Error(err))
+		}
+	}
+}
+
+// startMetricsServer serves /metrics on its own listener, for Prometheus
+// setups that can't reach the (auth-gated) main API. It returns nil without
+// starting anything when metricsPort is empty.
+func startMetricsServer(metricsPort string, registry *metrics.Registry, logger *zap.Logger) *http.Server {
+	if metricsPort == "" {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", registry.Handler())
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%s", metricsPort),
+		Handler: mux,
+	}
+
+	go func() {
+		logger.Info("Metrics server starting", zap.String("address", server.Addr))
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Fatal("Metrics server failed to start", zap.Error(err))
+		}
+	}()
+
+	return server
+}
+
+// startCommanderServer starts the gRPC Commander API (see
+// internal/api/grpc) on its own listener alongside the HTTP API, sharing
+// coreInstance so both surfaces check configs through the same pool. It
+// returns nil without starting anything when grpcPort is empty.
+func startCommanderServer(grpcPort string, coreInstance *core.Core, logger *zap.Logger) *grpc.Server {
+	if grpcPort == "" {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+	if err != nil {
+		logger.Fatal("Failed to listen for gRPC", zap.Error(err))
+	}
+
+	grpcServer := grpc.NewServer()
+	namiragrpc.Register(grpcServer, namiragrpc.NewService(coreInstance, logger))
+
+	go func() {
+		logger.Info("gRPC commander server starting", zap.String("address", listener.Addr().String()))
+		if err := grpcServer.Serve(listener); err != nil && err != grpc.ErrServerStopped {
+			logger.Fatal("gRPC server failed to start", zap.Error(err))
+		}
+	}()
+
+	return grpcServer
 }
 
 // Helper function to count valid configurations
@@ -244,3 +375,58 @@ func countValidConfigs(results []core.CheckResult) int {
 	}
 	return count
 }
+
+// newKeyring builds the crypto.Keyring the updater seals/opens results.txt
+// with. ENCRYPTION_KEYS, when set, takes precedence as a full
+// crypto.ParseKeyring spec; otherwise EncryptionKey alone is wrapped as a
+// single-entry keyring under EncryptionKeyActive so existing single-key
+// deployments keep working unchanged.
+func newKeyring(cfg config.AppConfig) (*crypto.Keyring, error) {
+	if cfg.EncryptionKeys != "" {
+		return crypto.ParseKeyring(cfg.EncryptionKeys, cfg.EncryptionKeyActive)
+	}
+	return crypto.NewKeyring(map[string][]byte{cfg.EncryptionKeyActive: []byte(cfg.EncryptionKey)}, cfg.EncryptionKeyActive)
+}
+
+// parseCheckEndpoints parses CHECK_ENDPOINTS entries of the form
+// "host:port:weight:region:maxInflight" (weight, region, and maxInflight are
+// optional and default to 1, "", and 0/unlimited respectively) into
+// core.CheckEndpoint values.
+func parseCheckEndpoints(specs []string) ([]core.CheckEndpoint, error) {
+	endpoints := make([]core.CheckEndpoint, 0, len(specs))
+	for _, spec := range specs {
+		parts := strings.Split(spec, ":")
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("invalid check endpoint %q: expected host:port[:weight:region:maxInflight]", spec)
+		}
+
+		host := parts[0]
+		port, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid check endpoint %q: bad port: %w", spec, err)
+		}
+
+		endpoint := core.CheckEndpoint{Host: host, Port: uint32(port), Weight: 1}
+
+		if len(parts) > 2 && parts[2] != "" {
+			weight, err := strconv.Atoi(parts[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid check endpoint %q: bad weight: %w", spec, err)
+			}
+			endpoint.Weight = weight
+		}
+		if len(parts) > 3 {
+			endpoint.Region = parts[3]
+		}
+		if len(parts) > 4 && parts[4] != "" {
+			maxInflight, err := strconv.Atoi(parts[4])
+			if err != nil {
+				return nil, fmt.Errorf("invalid check endpoint %q: bad max inflight: %w", spec, err)
+			}
+			endpoint.MaxInflight = maxInflight
+		}
+
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
@@ -6,9 +6,9 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/NamiraNet/namira-core/internal/cli"
-	"github.com/NamiraNet/namira-core/internal/core"
-	"github.com/NamiraNet/namira-core/internal/logger"
+	"github.com/NaMiraNet/namira-core/internal/cli"
+	"github.com/NaMiraNet/namira-core/internal/core"
+	"github.com/NaMiraNet/namira-core/internal/logger"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
@@ -38,20 +38,10 @@ func init() {
 	checkCmd.Flags().DurationVarP(&timeout, "timeout", "t", 10*time.Second, "Timeout for each check")
 }
 
-func runCli(cmd *cobra.Command, args []string) {
-	logger, err := logger.InitForCLI(cfg.App.LogLevel)
-	if err != nil {
-		fmt.Println("Failed to initialize logger:", err)
-		return
-	}
-	defer func() {
-		if syncErr := logger.Sync(); syncErr != nil {
-			fmt.Printf("Failed to sync logger: %v\n", syncErr)
-		}
-	}()
-
-	var configs []string
-
+// newCoreInstance builds the *core.Core shared by every CLI subcommand that
+// runs checks directly (as opposed to talking to a running namira-core API),
+// from the same cfg.App settings the "check" command has always used.
+func newCoreInstance(logger *zap.Logger) *core.Core {
 	checkServer, checkP, err := net.SplitHostPort(cfg.App.CheckHost)
 	if err != nil {
 		logger.Fatal("Failed to parse check server", zap.Error(err))
@@ -59,7 +49,7 @@ func runCli(cmd *cobra.Command, args []string) {
 
 	checkPort, _ := strconv.Atoi(checkP)
 
-	coreInstance := core.NewCore(core.CoreOpts{
+	return core.NewCore(core.CoreOpts{
 		CheckTimeout:       cfg.App.Timeout,
 		CheckServer:        checkServer,
 		CheckPort:          uint32(checkPort),
@@ -72,6 +62,23 @@ func runCli(cmd *cobra.Command, args []string) {
 			ShowProtocol: true,
 		},
 	})
+}
+
+func runCli(cmd *cobra.Command, args []string) {
+	logger, err := logger.InitForCLI(cfg.App.LogLevel)
+	if err != nil {
+		fmt.Println("Failed to initialize logger:", err)
+		return
+	}
+	defer func() {
+		if syncErr := logger.Sync(); syncErr != nil {
+			fmt.Printf("Failed to sync logger: %v\n", syncErr)
+		}
+	}()
+
+	var configs []string
+
+	coreInstance := newCoreInstance(logger)
 
 	// register cli
 	cli.NewCLI(coreInstance)
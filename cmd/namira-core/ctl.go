@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	namiragrpc "github.com/NaMiraNet/namira-core/internal/api/grpc"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var ctlAddr string
+
+var ctlCmd = &cobra.Command{
+	Use:   "ctl",
+	Short: "Control a running namira-core instance over its gRPC commander API",
+	Long:  `namira-core ctl talks to the gRPC commander API (see cmd api --grpc-port) to inspect and drive a running instance.`,
+}
+
+var ctlStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Print the target instance's live stats",
+	Run:   runCtlStats,
+}
+
+var ctlCancelCmd = &cobra.Command{
+	Use:   "cancel <job-id>",
+	Short: "Cancel a running job",
+	Args:  cobra.ExactArgs(1),
+	Run:   runCtlCancel,
+}
+
+var ctlTailCmd = &cobra.Command{
+	Use:   "tail <job-id>",
+	Short: "Stream results for a job as they complete",
+	Args:  cobra.ExactArgs(1),
+	Run:   runCtlTail,
+}
+
+func init() {
+	ctlCmd.PersistentFlags().StringVar(&ctlAddr, "addr", "localhost:9090", "gRPC commander address")
+	ctlCmd.AddCommand(ctlStatsCmd, ctlCancelCmd, ctlTailCmd)
+}
+
+func dialCtl() *namiragrpc.Client {
+	conn, err := grpc.NewClient(ctlAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to dial %s: %v\n", ctlAddr, err)
+		os.Exit(1)
+	}
+	return namiragrpc.NewClient(conn)
+}
+
+func runCtlStats(cmd *cobra.Command, args []string) {
+	client := dialCtl()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stats, err := client.GetStats(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "GetStats failed: %v\n", err)
+		os.Exit(1)
+	}
+	out, _ := json.MarshalIndent(stats, "", "  ")
+	fmt.Println(string(out))
+}
+
+func runCtlCancel(cmd *cobra.Command, args []string) {
+	client := dialCtl()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := client.CancelJob(ctx, args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "CancelJob failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("cancelled: %v\n", result.Cancelled)
+}
+
+func runCtlTail(cmd *cobra.Command, args []string) {
+	client := dialCtl()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	err := client.StreamResults(ctx, args[0], func(result *namiragrpc.CheckResult) {
+		out, _ := json.Marshal(result)
+		fmt.Println(string(out))
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "StreamResults failed: %v\n", err)
+		os.Exit(1)
+	}
+}
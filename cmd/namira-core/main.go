@@ -7,11 +7,11 @@ import (
 	"strings"
 	"time"
 
-	"github.com/NamiraNet/namira-core/internal/config"
-	"github.com/NamiraNet/namira-core/internal/core"
-	"github.com/NamiraNet/namira-core/internal/github"
-	"github.com/NamiraNet/namira-core/internal/logger"
-	"github.com/go-redis/redis/v8"
+	"github.com/NaMiraNet/namira-core/internal/config"
+	"github.com/NaMiraNet/namira-core/internal/core"
+	"github.com/NaMiraNet/namira-core/internal/github"
+	"github.com/NaMiraNet/namira-core/internal/logger"
+	"github.com/redis/go-redis/v9"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
@@ -39,10 +39,11 @@ var (
 	timeout       time.Duration
 	maxConcurrent int
 	checkHost     string
+	geoipDBPath   string
 
 	cfg         *config.Config
 	updater     *github.Updater
-	redisClient *redis.Client
+	redisClient redis.UniversalClient
 	appLogger   *zap.Logger
 )
 
@@ -56,10 +57,13 @@ func init() {
 	fmt.Println(VersionStatement())
 
 	// Load configuration from environment variables
-	cfg = config.Load()
+	var err error
+	cfg, err = config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
 
 	// Initialize logger
-	var err error
 	appLogger, err = logger.Init(cfg.App.LogLevel)
 	if err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
@@ -69,6 +73,7 @@ func init() {
 	rootCmd.PersistentFlags().DurationVarP(&timeout, "timeout", "t", core.DefaultCheckTimeout, "Connection timeout")
 	rootCmd.PersistentFlags().IntVarP(&maxConcurrent, "concurrent", "c", 0, "Maximum concurrent connections")
 	rootCmd.PersistentFlags().StringVarP(&checkHost, "host", "H", "", "Host to check")
+	rootCmd.PersistentFlags().StringVar(&geoipDBPath, "geoip-db", "", "Path to a GeoLite2-Country.mmdb")
 
 	if cfg.Server.Port == "" {
 		cfg.Server.Port = port
@@ -82,10 +87,17 @@ func init() {
 	if cfg.App.CheckHost == "" {
 		cfg.App.CheckHost = checkHost
 	}
+	if cfg.App.GeoIPDBPath == "" {
+		cfg.App.GeoIPDBPath = geoipDBPath
+	}
 
 	// Add the API server subcommand
 	rootCmd.AddCommand(apiCmd)
 	rootCmd.AddCommand(checkCmd)
+	rootCmd.AddCommand(ctlCmd)
+	rootCmd.AddCommand(replayCmd)
+	rootCmd.AddCommand(notifyCmd)
+	rootCmd.AddCommand(raypingCmd)
 }
 
 func main() {
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/NaMiraNet/namira-core/internal/core"
+	"github.com/NaMiraNet/namira-core/internal/logger"
+	"github.com/NaMiraNet/namira-core/internal/notify"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var notifyTest bool
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Inspect or exercise the notify.NotifierRegistry",
+	Long: `Loads the channel config pointed at by NOTIFY_CONFIG_PATH and, with
+--test, pushes a synthetic CheckResult through every channel so their
+credentials and connectivity can be verified without running a real scan.`,
+	Run: runNotify,
+}
+
+func init() {
+	notifyCmd.Flags().BoolVar(&notifyTest, "test", false, "Dispatch a synthetic result through every configured channel")
+}
+
+func runNotify(cmd *cobra.Command, args []string) {
+	logger, err := logger.InitForCLI(cfg.App.LogLevel)
+	if err != nil {
+		fmt.Println("Failed to initialize logger:", err)
+		return
+	}
+	defer func() {
+		if syncErr := logger.Sync(); syncErr != nil {
+			fmt.Printf("Failed to sync logger: %v\n", syncErr)
+		}
+	}()
+
+	if cfg.App.NotifyConfigPath == "" {
+		logger.Error("NOTIFY_CONFIG_PATH is not set, nothing to load")
+		return
+	}
+
+	notifyCfg, err := notify.LoadConfig(cfg.App.NotifyConfigPath)
+	if err != nil {
+		logger.Error("failed to load notify config", zap.Error(err))
+		return
+	}
+	logger.Info("notify config loaded",
+		zap.String("path", cfg.App.NotifyConfigPath),
+		zap.Int("channels", len(notifyCfg.Channels)))
+
+	if !notifyTest {
+		return
+	}
+
+	registry, err := notify.Build(notifyCfg, logger)
+	if err != nil {
+		logger.Error("failed to build notify registry", zap.Error(err))
+		return
+	}
+
+	registry.Dispatch(core.CheckResult{
+		Status:      core.CheckResultStatusSuccess,
+		Protocol:    "vmess",
+		Raw:         "vmess://eyJ2IjoiMiIsInBzIjoibmFtaXJhLXRlc3QifQ==",
+		RealDelay:   120 * time.Millisecond,
+		Remark:      "namira-notify-test",
+		Server:      "test.namira.example:443",
+		CountryCode: "US",
+	})
+
+	logger.Info("test result dispatched to every configured channel")
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/NaMiraNet/namira-core/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var (
+	retryTimeout time.Duration
+	retrySleep   time.Duration
+)
+
+var raypingCmd = &cobra.Command{
+	Use:   "rayping",
+	Short: "Start the standalone RayPing link-testing server",
+	Long: `Start a minimal HTTP server that tests VPN links for real reachability
+(TCP/TLS/WS/gRPC handshakes) without the rest of the API server's scanning
+and storage pipeline.`,
+	Run: runRayping,
+}
+
+func init() {
+	raypingCmd.Flags().DurationVar(&retryTimeout, "retry-timeout", 0, "Total time to keep retrying a failed link before giving up (0 disables retries)")
+	raypingCmd.Flags().DurationVar(&retrySleep, "retry-sleep", time.Second, "Time to sleep between retry attempts")
+}
+
+func runRayping(cmd *cobra.Command, args []string) {
+	rps := service.NewRayPingService(cfg.App.Timeout, cfg.App.MaxConcurrent, service.WithRetry(retryTimeout, retrySleep))
+	if err := rps.StartServer(cfg.Server.Port); err != nil {
+		log.Fatalf("Failed to start rayping server: %v", err)
+	}
+}
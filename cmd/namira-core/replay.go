@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/NaMiraNet/namira-core/internal/api"
+	"github.com/NaMiraNet/namira-core/internal/cli"
+	"github.com/NaMiraNet/namira-core/internal/logger"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <file>",
+	Short: "Replay a captured /scan payload from disk",
+	Long: `Reads a JSON payload captured by the API's scan reproducer (an
+api.CapturedScan, such as one saved from GET /scan/{id}/replay) or a raw
+{"configs": [...]} request body, and feeds the configs through the same
+checker the "check" command uses.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runReplay,
+}
+
+func init() {
+	replayCmd.Flags().StringVarP(&outputFormat, "format", "f", "table", "Output format: table, json, csv")
+	replayCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file (default: stdout)")
+	replayCmd.Flags().BoolVar(&showProgress, "progress", true, "Show progress during checking")
+}
+
+func runReplay(cmd *cobra.Command, args []string) {
+	logger, err := logger.InitForCLI(cfg.App.LogLevel)
+	if err != nil {
+		fmt.Println("Failed to initialize logger:", err)
+		return
+	}
+	defer func() {
+		if syncErr := logger.Sync(); syncErr != nil {
+			fmt.Printf("Failed to sync logger: %v\n", syncErr)
+		}
+	}()
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		logger.Error("failed to read replay file", zap.Error(err))
+		return
+	}
+
+	configs, err := extractReplayConfigs(data)
+	if err != nil {
+		logger.Error("failed to parse replay file", zap.Error(err))
+		return
+	}
+
+	processor := cli.NewConfigProcessor()
+	uniqueConfigs := processor.RemoveDuplicates(configs)
+
+	logger.Info("replay payload loaded",
+		zap.String("file", args[0]),
+		zap.Int("total", len(configs)),
+		zap.Int("unique", len(uniqueConfigs)))
+
+	coreInstance := newCoreInstance(logger)
+	checker := cli.NewChecker(coreInstance)
+	results := checker.PerformChecks(uniqueConfigs, cli.CheckOptions{ShowProgress: showProgress})
+
+	outputManager := cli.NewOutputManager()
+	if err := outputManager.Output(results, cli.OutputOptions{Format: outputFormat, Filename: outputFile}); err != nil {
+		logger.Error("failed to output results", zap.Error(err))
+		return
+	}
+
+	cli.NewSummaryPrinter().PrintSummary(results)
+}
+
+// extractReplayConfigs accepts either a full api.CapturedScan (whose Body is
+// itself the original /scan JSON request) or a bare {"configs": [...]}
+// payload, so a file saved straight from the API's replay capture works
+// without any reformatting.
+func extractReplayConfigs(data []byte) ([]string, error) {
+	var captured api.CapturedScan
+	if err := json.Unmarshal(data, &captured); err == nil && len(captured.Body) > 0 {
+		var req api.ScanRequest
+		if err := json.Unmarshal(captured.Body, &req); err == nil && len(req.Configs) > 0 {
+			return req.Configs, nil
+		}
+	}
+
+	var req api.ScanRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("unrecognized replay payload: %w", err)
+	}
+	if len(req.Configs) == 0 {
+		return nil, fmt.Errorf("replay payload contains no configs")
+	}
+	return req.Configs, nil
+}
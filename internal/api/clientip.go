@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type contextKey string
+
+const clientIPContextKey contextKey = "client_ip"
+
+// ClientIP returns the client IP resolved by ClientIPMiddleware. If the
+// middleware was never installed it falls back to r.RemoteAddr.
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPContextKey).(string); ok && ip != "" {
+		return ip
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ClientIPMiddleware resolves the real client IP for requests arriving
+// through a reverse proxy and stores it on the request context for ClientIP
+// to read.
+//
+// X-Forwarded-For/X-Real-IP are only consulted when the request's immediate
+// peer (RemoteAddr) is itself a trusted proxy; otherwise they're attacker
+// controlled and RemoteAddr is returned as-is. Once that's established, it
+// walks X-Forwarded-For from right to left, skipping any address that falls
+// inside a trusted proxy CIDR, then falls back to X-Real-IP and finally
+// RemoteAddr. When trustedProxies is empty, RemoteAddr is used as-is so
+// behavior is unchanged for deployments without a reverse proxy.
+func ClientIPMiddleware(trustedProxies []string) func(http.Handler) http.Handler {
+	trusted := parseTrustedProxies(trustedProxies)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := resolveClientIP(r, trusted)
+			ctx := context.WithValue(r.Context(), clientIPContextKey, ip)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func resolveClientIP(r *http.Request, trusted []*net.IPNet) string {
+	remoteHost, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remoteHost = r.RemoteAddr
+	}
+
+	if len(trusted) == 0 {
+		return remoteHost
+	}
+
+	remoteIP := net.ParseIP(remoteHost)
+	if remoteIP == nil || !isTrustedProxy(remoteIP, trusted) {
+		return remoteHost
+	}
+
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		parts := strings.Split(xff, ",")
+		for i := len(parts) - 1; i >= 0; i-- {
+			candidate := strings.TrimSpace(parts[i])
+			ip := net.ParseIP(candidate)
+			if ip == nil || isTrustedProxy(ip, trusted) {
+				continue
+			}
+			return candidate
+		}
+	}
+
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); realIP != "" {
+		if ip := net.ParseIP(realIP); ip != nil {
+			return ip.String()
+		}
+	}
+
+	return remoteHost
+}
+
+func parseTrustedProxies(cidrs []string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if !strings.Contains(cidr, "/") {
+			if strings.Contains(cidr, ":") {
+				cidr += "/128"
+			} else {
+				cidr += "/32"
+			}
+		}
+		if _, network, err := net.ParseCIDR(cidr); err == nil {
+			networks = append(networks, network)
+		}
+	}
+	return networks
+}
+
+func isTrustedProxy(ip net.IP, trusted []*net.IPNet) bool {
+	for _, network := range trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
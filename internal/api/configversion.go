@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// activeVersionKey points at the config version handleScan/filterDuplicates
+// currently read and write against.
+const activeVersionKey = "config:active_version"
+
+// gcLookbackVersions bounds how far back GC scans for stale versions, so a
+// long gap between refreshes (or a restart) doesn't make it walk from 0.
+const gcLookbackVersions = 50
+
+// ConfigVersionStore dedups checked configs behind a monotonically
+// increasing version namespace (config:v{N}:{hash}) instead of a single flat
+// config:{hash} key. This lets performBackgroundRefresh populate version
+// N+1 in the background, entirely independent of whatever handleScan is
+// reading and writing against version N, and flip readers/writers over with
+// one atomic SET once the refresh finishes — no write lock held for the
+// refresh's duration.
+type ConfigVersionStore struct {
+	redis redis.UniversalClient
+	ttl   time.Duration
+}
+
+func NewConfigVersionStore(redisClient redis.UniversalClient, ttl time.Duration) *ConfigVersionStore {
+	return &ConfigVersionStore{redis: redisClient, ttl: ttl}
+}
+
+// ActiveVersion returns the version handleScan and a running refresh's
+// predecessor state currently serve from. It defaults to 0 when no refresh
+// has ever activated a version.
+func (s *ConfigVersionStore) ActiveVersion(ctx context.Context) (int64, error) {
+	val, err := s.redis.Get(ctx, activeVersionKey).Result()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(val, 10, 64)
+}
+
+// NextVersion returns the version a background refresh should populate:
+// always the active version's successor.
+func (s *ConfigVersionStore) NextVersion(ctx context.Context) (int64, error) {
+	active, err := s.ActiveVersion(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return active + 1, nil
+}
+
+func configVersionKey(version int64, hash string) string {
+	return fmt.Sprintf("config:v%d:%s", version, hash)
+}
+
+// FilterDuplicates checks configs against version's dedup set and marks the
+// survivors as seen, returning only configs not already present at that
+// version.
+func (s *ConfigVersionStore) FilterDuplicates(ctx context.Context, version int64, configs []string) ([]string, error) {
+	unique := make([]string, 0, len(configs))
+	hashes := make([]string, len(configs))
+
+	pipe := s.redis.Pipeline()
+	cmds := make([]*redis.IntCmd, len(configs))
+	for i, config := range configs {
+		hash := HashConfig(config)
+		hashes[i] = hash
+		cmds[i] = pipe.Exists(ctx, configVersionKey(version, hash))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	pipe = s.redis.Pipeline()
+	for i, cmd := range cmds {
+		if cmd.Val() == 0 {
+			unique = append(unique, configs[i])
+			pipe.Set(ctx, configVersionKey(version, hashes[i]), "1", s.ttl)
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	return unique, nil
+}
+
+// Activate atomically flips active_version so subsequent reads/writes land
+// on version.
+func (s *ConfigVersionStore) Activate(ctx context.Context, version int64) error {
+	return s.redis.Set(ctx, activeVersionKey, version, 0).Err()
+}
+
+// GC deletes dedup keys for versions older than the last two (activeVersion
+// and its immediate predecessor), bounding how much superseded version
+// history Redis accumulates across refreshes.
+func (s *ConfigVersionStore) GC(ctx context.Context, activeVersion int64, logger *zap.Logger) {
+	oldest := activeVersion - 2
+	if oldest < 0 {
+		return
+	}
+	from := oldest - gcLookbackVersions
+	if from < 0 {
+		from = 0
+	}
+
+	for v := oldest; v >= from; v-- {
+		if err := s.deletePattern(ctx, fmt.Sprintf("config:v%d:*", v)); err != nil {
+			logger.Error("Failed to GC config version", zap.Int64("version", v), zap.Error(err))
+		}
+	}
+}
+
+func (s *ConfigVersionStore) deletePattern(ctx context.Context, pattern string) error {
+	const batchSize = 1000
+
+	iter := s.redis.Scan(ctx, 0, pattern, 0).Iterator()
+	batch := make([]string, 0, batchSize)
+	for iter.Next(ctx) {
+		batch = append(batch, iter.Val())
+		if len(batch) == batchSize {
+			if err := s.redis.Del(ctx, batch...).Err(); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		if err := s.redis.Del(ctx, batch...).Err(); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
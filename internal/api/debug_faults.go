@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/NaMiraNet/namira-core/internal/faultproxy"
+)
+
+// faultRuleRequest is the POST /debug/faults body used to install or remove
+// a fault rule. Action is "install" or "remove"; Profile is only read for
+// "install". Durations are given in milliseconds to keep the payload plain JSON.
+type faultRuleRequest struct {
+	Action          string  `json:"action"`
+	HostPattern     string  `json:"host_pattern"`
+	AddedLatencyMs  int64   `json:"added_latency_ms"`
+	JitterMeanMs    int64   `json:"jitter_mean_ms"`
+	JitterStdDevMs  int64   `json:"jitter_stddev_ms"`
+	DropProbability float64 `json:"drop_probability"`
+	RST             bool    `json:"rst"`
+	BandwidthBps    int64   `json:"bandwidth_bps"`
+	Blackhole       bool    `json:"blackhole"`
+}
+
+type faultRuleResponse struct {
+	HostPattern     string  `json:"host_pattern"`
+	AddedLatencyMs  int64   `json:"added_latency_ms"`
+	JitterMeanMs    int64   `json:"jitter_mean_ms"`
+	JitterStdDevMs  int64   `json:"jitter_stddev_ms"`
+	DropProbability float64 `json:"drop_probability"`
+	RST             bool    `json:"rst"`
+	BandwidthBps    int64   `json:"bandwidth_bps"`
+	Blackhole       bool    `json:"blackhole"`
+}
+
+// handleDebugFaults installs or removes a faultproxy rule at runtime, and
+// lists the currently installed rules. It is only mounted when the operator
+// enables DEBUG_FAULTS_ENABLED, and sits behind the same X-API-Key check as
+// every other non-health route.
+func (h *Handler) handleDebugFaults(w http.ResponseWriter, r *http.Request) {
+	if h.faultRegistry == nil {
+		http.Error(w, "fault injection is not enabled", http.StatusNotFound)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		writeJSON(w, rulesToResponse(h.faultRegistry.Rules()))
+		return
+	}
+
+	var req faultRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.HostPattern == "" {
+		http.Error(w, "host_pattern is required", http.StatusBadRequest)
+		return
+	}
+
+	switch req.Action {
+	case "remove":
+		h.faultRegistry.Remove(req.HostPattern)
+		writeJSON(w, rulesToResponse(h.faultRegistry.Rules()))
+	case "install", "":
+		profile := faultproxy.Profile{
+			AddedLatency:    time.Duration(req.AddedLatencyMs) * time.Millisecond,
+			JitterMean:      time.Duration(req.JitterMeanMs) * time.Millisecond,
+			JitterStdDev:    time.Duration(req.JitterStdDevMs) * time.Millisecond,
+			DropProbability: req.DropProbability,
+			RST:             req.RST,
+			BandwidthBps:    req.BandwidthBps,
+			Blackhole:       req.Blackhole,
+		}
+		if err := h.faultRegistry.Install(req.HostPattern, profile); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, rulesToResponse(h.faultRegistry.Rules()))
+	default:
+		http.Error(w, "action must be \"install\" or \"remove\"", http.StatusBadRequest)
+	}
+}
+
+func rulesToResponse(rules []faultproxy.Rule) []faultRuleResponse {
+	out := make([]faultRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		out = append(out, faultRuleResponse{
+			HostPattern:     rule.HostPattern,
+			AddedLatencyMs:  rule.Profile.AddedLatency.Milliseconds(),
+			JitterMeanMs:    rule.Profile.JitterMean.Milliseconds(),
+			JitterStdDevMs:  rule.Profile.JitterStdDev.Milliseconds(),
+			DropProbability: rule.Profile.DropProbability,
+			RST:             rule.Profile.RST,
+			BandwidthBps:    rule.Profile.BandwidthBps,
+			Blackhole:       rule.Profile.Blackhole,
+		})
+	}
+	return out
+}
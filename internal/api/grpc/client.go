@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc"
+)
+
+// Client is a thin Commander client for the `namira-core ctl` subcommand.
+type Client struct {
+	conn *grpc.ClientConn
+}
+
+func NewClient(conn *grpc.ClientConn) *Client {
+	return &Client{conn: conn}
+}
+
+func (c *Client) GetStats(ctx context.Context) (*CoreStats, error) {
+	out := new(CoreStats)
+	err := c.conn.Invoke(ctx, "/namira.commander.v1.Commander/GetStats", &Empty{}, out, grpc.CallContentSubtype(codecName))
+	return out, err
+}
+
+func (c *Client) CancelJob(ctx context.Context, jobID string) (*CancelResult, error) {
+	out := new(CancelResult)
+	err := c.conn.Invoke(ctx, "/namira.commander.v1.Commander/CancelJob", &JobID{JobID: jobID}, out, grpc.CallContentSubtype(codecName))
+	return out, err
+}
+
+func (c *Client) SubmitJob(ctx context.Context, lines []string) (*JobHandle, error) {
+	stream, err := c.conn.NewStream(ctx,
+		&grpc.StreamDesc{StreamName: "SubmitJob", ClientStreams: true},
+		"/namira.commander.v1.Commander/SubmitJob",
+		grpc.CallContentSubtype(codecName))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range lines {
+		if err := stream.SendMsg(&ConfigLine{Line: line}); err != nil {
+			return nil, err
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	out := new(JobHandle)
+	if err := stream.RecvMsg(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StreamResults calls onResult for each CheckResult as it arrives, returning
+// once the job finishes (EOF) or the stream errors.
+func (c *Client) StreamResults(ctx context.Context, jobID string, onResult func(*CheckResult)) error {
+	stream, err := c.conn.NewStream(ctx,
+		&grpc.StreamDesc{StreamName: "StreamResults", ServerStreams: true},
+		"/namira.commander.v1.Commander/StreamResults",
+		grpc.CallContentSubtype(codecName))
+	if err != nil {
+		return err
+	}
+	if err := stream.SendMsg(&JobID{JobID: jobID}); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+
+	for {
+		msg := new(CheckResult)
+		if err := stream.RecvMsg(msg); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		onResult(msg)
+	}
+}
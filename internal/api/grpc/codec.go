@@ -0,0 +1,25 @@
+package grpc
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// codecName is registered with grpc-go as the wire codec for this service.
+// Clients must dial with grpc.CallContentSubtype(codecName) (see client.go)
+// since "json" isn't grpc-go's built-in default ("proto").
+const codecName = "json"
+
+// jsonCodec lets Commander run over real google.golang.org/grpc transport
+// and streaming semantics without a protoc-gen-go code generation step; see
+// commander.proto for the rationale.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                       { return codecName }
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
@@ -0,0 +1,55 @@
+package grpc
+
+// Message types for the Commander service (see commander.proto). These are
+// plain Go structs carried over the "json" codec registered in codec.go
+// rather than generated protobuf types — see commander.proto for why.
+
+type ConfigLine struct {
+	Line string `json:"line"`
+}
+
+type JobHandle struct {
+	JobID  string `json:"job_id"`
+	Queued int32  `json:"queued"`
+}
+
+type JobID struct {
+	JobID string `json:"job_id"`
+}
+
+type CheckResult struct {
+	Status      string `json:"status"`
+	Protocol    string `json:"protocol"`
+	Raw         string `json:"raw"`
+	DelayMs     int64  `json:"delay_ms"`
+	Remark      string `json:"remark"`
+	Server      string `json:"server"`
+	CountryCode string `json:"country_code"`
+	Error       string `json:"error"`
+	HTTPStatus  int32  `json:"http_status"`
+	TLSVersion  string `json:"tls_version"`
+	ResolvedIP  string `json:"resolved_ip"`
+}
+
+type CancelResult struct {
+	Cancelled bool `json:"cancelled"`
+}
+
+type CoreStats struct {
+	InFlight            int32              `json:"in_flight"`
+	FDUsed              int32              `json:"fd_used"`
+	FDLimit             int32              `json:"fd_limit"`
+	MemoryBytes         uint64             `json:"memory_bytes"`
+	ProtocolSuccessRate map[string]float64 `json:"protocol_success_rate"`
+}
+
+type ParserConfig struct {
+	Protocols []string `json:"protocols"`
+}
+
+type ReloadResult struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+type Empty struct{}
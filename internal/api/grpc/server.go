@@ -0,0 +1,168 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/NaMiraNet/namira-core/internal/core"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// jobState tracks one SubmitJob's in-flight results. It lives only in
+// process memory: unlike the HTTP API's jobs, Commander is meant for a
+// single supervising controller talking to one instance over a long-lived
+// connection, not for results to survive a restart.
+type jobState struct {
+	mu      sync.Mutex
+	results []CheckResult
+	done    bool
+	cancel  context.CancelFunc
+}
+
+// Service implements CommanderServer against a shared core.Core, the same
+// instance the HTTP API checks configs through.
+type Service struct {
+	core   *core.Core
+	logger *zap.Logger
+	jobs   sync.Map // jobID (string) -> *jobState
+}
+
+// NewService builds a Commander service backed by c. logger may be nil.
+func NewService(c *core.Core, logger *zap.Logger) *Service {
+	return &Service{core: c, logger: logger}
+}
+
+func (s *Service) SubmitJob(stream SubmitJobServer) error {
+	var lines []string
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Line != "" {
+			lines = append(lines, msg.Line)
+		}
+	}
+
+	jobID := uuid.NewString()
+	ctx, cancel := context.WithCancel(context.Background())
+	state := &jobState{cancel: cancel}
+	s.jobs.Store(jobID, state)
+
+	go func() {
+		defer cancel()
+		for result := range s.core.CheckConfigs(lines) {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+			state.mu.Lock()
+			state.results = append(state.results, toGRPCResult(result))
+			state.mu.Unlock()
+		}
+		state.mu.Lock()
+		state.done = true
+		state.mu.Unlock()
+	}()
+
+	return stream.SendAndClose(&JobHandle{JobID: jobID, Queued: int32(len(lines))})
+}
+
+func (s *Service) StreamResults(req *JobID, stream StreamResultsServer) error {
+	value, ok := s.jobs.Load(req.JobID)
+	if !ok {
+		return fmt.Errorf("unknown job %q", req.JobID)
+	}
+	state := value.(*jobState)
+
+	sent := 0
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		state.mu.Lock()
+		pending := append([]CheckResult(nil), state.results[sent:]...)
+		done := state.done
+		state.mu.Unlock()
+
+		for i := range pending {
+			if err := stream.Send(&pending[i]); err != nil {
+				return err
+			}
+		}
+		sent += len(pending)
+
+		if done {
+			return nil
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) CancelJob(ctx context.Context, req *JobID) (*CancelResult, error) {
+	value, ok := s.jobs.Load(req.JobID)
+	if !ok {
+		return &CancelResult{Cancelled: false}, nil
+	}
+	value.(*jobState).cancel()
+	return &CancelResult{Cancelled: true}, nil
+}
+
+func (s *Service) GetStats(ctx context.Context, req *Empty) (*CoreStats, error) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	var inFlight int32
+	s.jobs.Range(func(_, v any) bool {
+		state := v.(*jobState)
+		state.mu.Lock()
+		if !state.done {
+			inFlight++
+		}
+		state.mu.Unlock()
+		return true
+	})
+
+	return &CoreStats{
+		InFlight:            inFlight,
+		MemoryBytes:         mem.Sys,
+		ProtocolSuccessRate: map[string]float64{},
+	}, nil
+}
+
+// ReloadParsers is not wired up yet: core.Core does not currently expose its
+// parser registry for a safe runtime swap, so this reports the gap instead
+// of silently no-op'ing.
+func (s *Service) ReloadParsers(ctx context.Context, req *ParserConfig) (*ReloadResult, error) {
+	return &ReloadResult{OK: false, Error: "reload not supported: core.Core does not expose its parser registry"}, nil
+}
+
+func toGRPCResult(r core.CheckResult) CheckResult {
+	return CheckResult{
+		Status:      string(r.Status),
+		Protocol:    r.Protocol,
+		Raw:         r.Raw,
+		DelayMs:     r.RealDelay.Milliseconds(),
+		Remark:      r.Remark,
+		Server:      r.Server,
+		CountryCode: r.CountryCode,
+		Error:       r.Error,
+		HTTPStatus:  int32(r.HTTPStatus),
+		TLSVersion:  r.TLSVersion,
+		ResolvedIP:  r.ResolvedIP,
+	}
+}
@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// CommanderServer is the hand-wired equivalent of what protoc-gen-go-grpc
+// would emit from commander.proto's Commander service.
+type CommanderServer interface {
+	SubmitJob(stream SubmitJobServer) error
+	StreamResults(req *JobID, stream StreamResultsServer) error
+	CancelJob(ctx context.Context, req *JobID) (*CancelResult, error)
+	GetStats(ctx context.Context, req *Empty) (*CoreStats, error)
+	ReloadParsers(ctx context.Context, req *ParserConfig) (*ReloadResult, error)
+}
+
+type SubmitJobServer interface {
+	Recv() (*ConfigLine, error)
+	SendAndClose(*JobHandle) error
+	grpc.ServerStream
+}
+
+type submitJobServer struct{ grpc.ServerStream }
+
+func (x *submitJobServer) Recv() (*ConfigLine, error) {
+	m := new(ConfigLine)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (x *submitJobServer) SendAndClose(m *JobHandle) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+type StreamResultsServer interface {
+	Send(*CheckResult) error
+	grpc.ServerStream
+}
+
+type streamResultsServer struct{ grpc.ServerStream }
+
+func (x *streamResultsServer) Send(m *CheckResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Commander_SubmitJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CommanderServer).SubmitJob(&submitJobServer{stream})
+}
+
+func _Commander_StreamResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	req := new(JobID)
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+	return srv.(CommanderServer).StreamResults(req, &streamResultsServer{stream})
+}
+
+func _Commander_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommanderServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/namira.commander.v1.Commander/CancelJob"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommanderServer).CancelJob(ctx, req.(*JobID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Commander_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommanderServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/namira.commander.v1.Commander/GetStats"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommanderServer).GetStats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Commander_ReloadParsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParserConfig)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommanderServer).ReloadParsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/namira.commander.v1.Commander/ReloadParsers"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommanderServer).ReloadParsers(ctx, req.(*ParserConfig))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ServiceDesc is the grpc.ServiceDesc for Commander, equivalent to the
+// _Commander_serviceDesc protoc-gen-go-grpc would generate.
+var ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "namira.commander.v1.Commander",
+	HandlerType: (*CommanderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "CancelJob", Handler: _Commander_CancelJob_Handler},
+		{MethodName: "GetStats", Handler: _Commander_GetStats_Handler},
+		{MethodName: "ReloadParsers", Handler: _Commander_ReloadParsers_Handler},
+	},
+	Streams: []grpc.StreamDesc{
+		{StreamName: "SubmitJob", Handler: _Commander_SubmitJob_Handler, ClientStreams: true},
+		{StreamName: "StreamResults", Handler: _Commander_StreamResults_Handler, ServerStreams: true},
+	},
+	Metadata: "commander.proto",
+}
+
+// Register attaches the Commander service to a *grpc.Server.
+func Register(server *grpc.Server, svc CommanderServer) {
+	server.RegisterService(&ServiceDesc, svc)
+}
@@ -2,17 +2,22 @@ package api
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/NamiraNet/namira-core/internal/core"
-	"github.com/NamiraNet/namira-core/internal/github"
-	workerpool "github.com/NamiraNet/namira-core/internal/worker"
+	"github.com/NaMiraNet/namira-core/internal/core"
+	"github.com/NaMiraNet/namira-core/internal/export"
+	"github.com/NaMiraNet/namira-core/internal/faultproxy"
+	"github.com/NaMiraNet/namira-core/internal/github"
+	"github.com/NaMiraNet/namira-core/internal/notify"
+	workerpool "github.com/NaMiraNet/namira-core/internal/worker"
 	"github.com/gorilla/mux"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
@@ -32,26 +37,35 @@ type ConfigSuccessHandler func(core.CheckResult)
 type Handler struct {
 	core                  *core.Core
 	workerPool            *workerpool.WorkerPool
-	redis                 *redis.Client
+	redis                 redis.UniversalClient
 	jobs                  sync.Map
+	keyWatcher            *KeyWatcher
 	logger                *zap.Logger
 	updater               *github.Updater
 	jobsOnSuccess         ConfigSuccessHandler
 	versionInfo           VersionInfo
 	redisResultExpiration time.Duration
+	faultRegistry         *faultproxy.Registry
+	replayStore           *ScanReplayStore
+	notifyRegistry        *notify.NotifierRegistry
+	jobStore              *JobStore
+	versionStore          *ConfigVersionStore
 
 	// Background refresh components
-	refreshMutex    sync.RWMutex
 	refreshTicker   *time.Ticker
 	refreshInterval time.Duration
 	refreshDone     chan struct{}
 }
 
-func NewHandler(c *core.Core, redisClient *redis.Client, callbackHandler CallbackHandler, configSuccessHandler ConfigSuccessHandler, logger *zap.Logger, updater *github.Updater, worker *workerpool.WorkerPool, versionInfo VersionInfo, redisResultExpiration time.Duration, refreshInterval time.Duration) *Handler {
+func NewHandler(c *core.Core, redisClient redis.UniversalClient, callbackHandler CallbackHandler, configSuccessHandler ConfigSuccessHandler, logger *zap.Logger, updater *github.Updater, worker *workerpool.WorkerPool, versionInfo VersionInfo, redisResultExpiration time.Duration, refreshInterval time.Duration, faultRegistry *faultproxy.Registry, replayStore *ScanReplayStore, notifyRegistry *notify.NotifierRegistry) *Handler {
+	jobStore := NewJobStore(redisClient, redisResultExpiration)
+	versionStore := NewConfigVersionStore(redisClient, redisResultExpiration)
+
 	handler := &Handler{
 		core:                  c,
 		workerPool:            worker,
 		redis:                 redisClient,
+		keyWatcher:            NewKeyWatcher(redisClient, logger),
 		logger:                logger,
 		updater:               updater,
 		jobsOnSuccess:         configSuccessHandler,
@@ -59,6 +73,11 @@ func NewHandler(c *core.Core, redisClient *redis.Client, callbackHandler Callbac
 		redisResultExpiration: redisResultExpiration,
 		refreshInterval:       refreshInterval,
 		refreshDone:           make(chan struct{}),
+		faultRegistry:         faultRegistry,
+		replayStore:           replayStore,
+		notifyRegistry:        notifyRegistry,
+		jobStore:              jobStore,
+		versionStore:          versionStore,
 	}
 
 	worker.SetResultHandler(handler.handleTaskResult(callbackHandler))
@@ -66,12 +85,49 @@ func NewHandler(c *core.Core, redisClient *redis.Client, callbackHandler Callbac
 		panic("Failed to start worker pool: " + err.Error())
 	}
 
+	handler.requeueInterruptedJobs()
+
 	// Start background refresh
 	go handler.startBackgroundRefresh()
 
 	return handler
 }
 
+// requeueInterruptedJobs scans the JobStore for jobs still marked "running"
+// from before this process started — since worker.Start() above just reset
+// the pool, none of them has an active worker, so every one of them is a
+// scan interrupted by the previous restart. Each is resubmitted in full
+// under its original ID.
+func (h *Handler) requeueInterruptedJobs() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	running, err := h.jobStore.ListRunning(ctx)
+	if err != nil {
+		h.logger.Error("Failed to scan job store for interrupted jobs", zap.Error(err))
+		return
+	}
+
+	for _, job := range running {
+		h.logger.Info("Requeuing job interrupted by restart",
+			zap.String("job_id", job.ID),
+			zap.Int("done", job.DoneCount),
+			zap.Int("total", job.TotalCount))
+
+		h.trackJob(job)
+		job.Start()
+
+		if err := h.workerPool.Submit(workerpool.Task{
+			ID:      job.ID,
+			Data:    TaskData{JobID: job.ID, Configs: job.Configs},
+			Execute: h.executeCheckTask,
+		}); err != nil {
+			h.logger.Error("Failed to requeue interrupted job", zap.String("job_id", job.ID), zap.Error(err))
+			job.Fail(err)
+		}
+	}
+}
+
 func (h *Handler) startBackgroundRefresh() {
 	h.refreshTicker = time.NewTicker(h.refreshInterval)
 
@@ -90,15 +146,23 @@ func (h *Handler) startBackgroundRefresh() {
 	}()
 }
 
+// performBackgroundRefresh re-checks every known config under the next
+// config version (activeVersion+1) without touching the version handleScan
+// is currently reading and writing, then atomically activates it once the
+// refresh completes — so handleScan never blocks or 503s for a refresh's
+// duration, however long it runs.
 func (h *Handler) performBackgroundRefresh() {
 	h.logger.Info("Starting background refresh of all configurations")
 
-	// Acquire write lock - blocks all API operations
-	h.refreshMutex.Lock()
-	defer h.refreshMutex.Unlock()
-
+	ctx := context.Background()
 	start := time.Now()
 
+	writeVersion, err := h.versionStore.NextVersion(ctx)
+	if err != nil {
+		h.logger.Error("Failed to determine next config version", zap.Error(err))
+		return
+	}
+
 	configs, err := h.updater.GetCurrentConfigs()
 	if err != nil {
 		h.logger.Error("Failed to fetch current configs during refresh", zap.Error(err))
@@ -110,14 +174,16 @@ func (h *Handler) performBackgroundRefresh() {
 		return
 	}
 
-	if err := h.flushRedisCache(); err != nil {
-		h.logger.Error("Failed to flush Redis cache", zap.Error(err))
+	// Seed writeVersion's dedup set; the new version namespace starts empty,
+	// so there's nothing to flush the way a flat config:{hash} cache needed.
+	if _, err := h.versionStore.FilterDuplicates(ctx, writeVersion, configs); err != nil {
+		h.logger.Error("Failed to populate config version during refresh", zap.Int64("version", writeVersion), zap.Error(err))
 		return
 	}
 
 	job := NewJob(configs)
 	job.ID = "refresh-" + job.ID // Mark as refresh job
-	h.jobs.Store(job.ID, job)
+	h.trackJob(job)
 	job.Start()
 
 	if err := h.workerPool.Submit(workerpool.Task{
@@ -133,9 +199,16 @@ func (h *Handler) performBackgroundRefresh() {
 			}
 			job.Complete()
 
+			if err := h.versionStore.Activate(ctx, writeVersion); err != nil {
+				h.logger.Error("Failed to activate refreshed config version", zap.Int64("version", writeVersion), zap.Error(err))
+				return
+			}
+			go h.versionStore.GC(context.Background(), writeVersion, h.logger)
+
 			h.logger.Info("Background refresh completed",
 				zap.Duration("duration", time.Since(start)),
 				zap.Int("configs_refreshed", len(configs)),
+				zap.Int64("active_version", writeVersion),
 				zap.String("job_id", job.ID))
 		},
 	}); err != nil {
@@ -146,14 +219,6 @@ func (h *Handler) performBackgroundRefresh() {
 }
 
 func (h *Handler) handleScan(w http.ResponseWriter, r *http.Request) {
-	// Acquire read lock - allows concurrent API calls but blocks during refresh
-	if !h.refreshMutex.TryRLock() {
-		h.logger.Info("Background refresh in progress, skipping scan")
-		writeError(w, "Background refresh in progress", http.StatusServiceUnavailable)
-		return
-	}
-	defer h.refreshMutex.RUnlock()
-
 	var configs []string
 
 	// Check content type
@@ -196,7 +261,15 @@ func (h *Handler) handleScan(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	uniqueConfigs, err := h.filterDuplicates(configs)
+	ctx := r.Context()
+	version, err := h.versionStore.ActiveVersion(ctx)
+	if err != nil {
+		h.logger.Error("Failed to read active config version", zap.Error(err))
+		writeError(w, "Failed to filter duplicates", http.StatusInternalServerError)
+		return
+	}
+
+	uniqueConfigs, err := h.versionStore.FilterDuplicates(ctx, version, configs)
 	if err != nil {
 		h.logger.Error("Failed to filter duplicates", zap.Error(err))
 		writeError(w, "Failed to filter duplicates", http.StatusInternalServerError)
@@ -210,7 +283,7 @@ func (h *Handler) handleScan(w http.ResponseWriter, r *http.Request) {
 	}
 
 	job := NewJob(uniqueConfigs)
-	h.jobs.Store(job.ID, job)
+	h.trackJob(job)
 	job.Start()
 
 	if err := h.workerPool.Submit(workerpool.Task{
@@ -227,8 +300,42 @@ func (h *Handler) handleScan(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, ScanResponse{JobID: job.ID})
 }
 
+// handleScanReplay re-enqueues a previously captured /scan payload as a
+// fresh job, letting an operator replay a failing batch against a new build
+// without reconstructing it by hand.
+func (h *Handler) handleScanReplay(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	captured, err := h.replayStore.Get(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to load captured scan", zap.String("id", id), zap.Error(err))
+		writeError(w, "Captured scan not found", http.StatusNotFound)
+		return
+	}
+
+	replayReq, err := http.NewRequestWithContext(r.Context(), captured.Method, captured.Path, bytes.NewReader(captured.Body))
+	if err != nil {
+		h.logger.Error("Failed to rebuild captured request", zap.Error(err))
+		writeError(w, "Failed to rebuild captured request", http.StatusInternalServerError)
+		return
+	}
+	replayReq.Header = captured.Headers.Clone()
+
+	h.handleScan(w, replayReq)
+}
+
 func (h *Handler) handleJobStatus(w http.ResponseWriter, r *http.Request) {
-	if value, exists := h.jobs.Load(mux.Vars(r)["id"]); exists {
+	jobID := mux.Vars(r)["id"]
+
+	if format := r.URL.Query().Get("format"); format != "" {
+		h.handleJobExport(w, r, jobID, export.Format(format))
+		return
+	}
+
+	if value, exists := h.jobs.Load(jobID); exists {
 		writeJSON(w, value.(*Job))
 		return
 	}
@@ -236,8 +343,220 @@ func (h *Handler) handleJobStatus(w http.ResponseWriter, r *http.Request) {
 	writeError(w, "Job not found", http.StatusNotFound)
 }
 
+// handleJobsList serves a paginated, most-recent-first listing of jobs
+// known to the JobStore, via ?offset= and ?limit= (default 0/20, capped at
+// 100) — recent jobs survive an API restart even after they drop out of
+// the in-process Handler.jobs map.
+func (h *Handler) handleJobsList(w http.ResponseWriter, r *http.Request) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	jobs, err := h.jobStore.List(r.Context(), offset, limit)
+	if err != nil {
+		h.logger.Error("Failed to list jobs", zap.Error(err))
+		writeError(w, "Failed to list jobs", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, jobs)
+}
+
+// handleJobExport serves a completed job's results as a subscription
+// document (?format=base64|clash|sing-box), reading the full
+// []core.CheckResult that executeTask cached in Redis under
+// "scan_results:<jobID>" — the in-memory Job only keeps the slim
+// per-config CheckResult used for progress polling.
+func (h *Handler) handleJobExport(w http.ResponseWriter, r *http.Request, jobID string, format export.Format) {
+	if !export.Supported(format) {
+		writeError(w, fmt.Sprintf("unsupported export format: %s", format), http.StatusBadRequest)
+		return
+	}
+
+	data, err := h.redis.Get(r.Context(), fmt.Sprintf("scan_results:%s", jobID)).Bytes()
+	if err != nil {
+		h.logger.Error("Failed to load scan results for export", zap.String("job_id", jobID), zap.Error(err))
+		writeError(w, "Job results not found", http.StatusNotFound)
+		return
+	}
+
+	var scanResult github.ScanResult
+	if err := json.Unmarshal(data, &scanResult); err != nil {
+		h.logger.Error("Failed to unmarshal scan results", zap.String("job_id", jobID), zap.Error(err))
+		writeError(w, "Failed to read job results", http.StatusInternalServerError)
+		return
+	}
+
+	rendered, err := export.Render(scanResult.Results, format)
+	if err != nil {
+		h.logger.Error("Failed to render export", zap.String("job_id", jobID), zap.String("format", string(format)), zap.Error(err))
+		writeError(w, "Failed to render export", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(rendered)
+}
+
+// trackJob registers a job for in-process lookups and wires it to publish
+// progress events through the KeyWatcher so remote SSE subscribers (and other
+// API replicas) see updates as they happen, not just on poll.
+func (h *Handler) trackJob(job *Job) {
+	job.OnUpdate(func(event JobEvent) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := h.keyWatcher.Publish(ctx, event); err != nil {
+			h.logger.Error("Failed to publish job event", zap.String("job_id", event.JobID), zap.Error(err))
+		}
+		if err := h.jobStore.Save(ctx, job); err != nil {
+			h.logger.Error("Failed to persist job", zap.String("job_id", event.JobID), zap.Error(err))
+		}
+	})
+	h.jobs.Store(job.ID, job)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := h.jobStore.Save(ctx, job); err != nil {
+		h.logger.Error("Failed to persist new job", zap.String("job_id", job.ID), zap.Error(err))
+	}
+}
+
+// jobResultRecordFromStream converts one Redis Stream entry from
+// jobResultsKey back into the same JobEvent shape handleJobStream's live
+// path emits for a per-config result, so a client can't tell whether an
+// event arrived via backlog replay or live delivery.
+func jobResultRecordFromStream(jobID string, msg redis.XMessage) JobEvent {
+	delay, _ := strconv.ParseInt(fmt.Sprint(msg.Values["delay_ms"]), 10, 64)
+	return JobEvent{
+		JobID:      jobID,
+		ConfigHash: fmt.Sprint(msg.Values["config_hash"]),
+		Result: &CheckResult{
+			Status: fmt.Sprint(msg.Values["status"]),
+			Delay:  delay,
+			Error:  fmt.Sprint(msg.Values["error"]),
+		},
+	}
+}
+
+// handleJobStream streams job progress as Server-Sent Events, entirely over
+// the Redis-backed KeyWatcher/JobStore path so it behaves identically
+// regardless of which API replica served the original job or is serving
+// this request. A client reconnecting with a Last-Event-ID header (the
+// Redis Stream ID of the last per-config result it saw) first replays every
+// result appended to the job's stream since then — real Redis Stream IDs,
+// not an in-process counter, so replay works even if the original
+// connection was served by a different replica — before switching to live
+// coarse/per-config updates via the KeyWatcher subscription. A heartbeat
+// comment keeps idle connections from timing out through intermediate
+// proxies.
+func (h *Handler) handleJobStream(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)["id"]
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ctx := r.Context()
+
+	if lastID := r.Header.Get("Last-Event-ID"); lastID != "" {
+		backlog, err := h.jobStore.ReadResults(ctx, jobID, lastID)
+		if err != nil {
+			h.logger.Error("Failed to read job result backlog", zap.String("job_id", jobID), zap.Error(err))
+		}
+		for _, msg := range backlog {
+			data, err := json.Marshal(jobResultRecordFromStream(jobID, msg))
+			if err != nil {
+				h.logger.Error("Failed to marshal job result backlog entry", zap.Error(err))
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "id: %s\ndata: %s\n\n", msg.ID, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+
+	events, unsubscribe := h.keyWatcher.Subscribe(jobID)
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(15 * time.Second)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				h.logger.Error("Failed to marshal job event", zap.Error(err))
+				continue
+			}
+
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+
+			if event.Status == JobStatusCompleted || event.Status == JobStatusFailed {
+				return
+			}
+		}
+	}
+}
+
 func (h *Handler) handleHealth(w http.ResponseWriter, r *http.Request) {
 	stats := h.workerPool.GetStats()
+
+	endpointStatuses := h.core.EndpointStatuses()
+	endpoints := make([]EndpointStatus, 0, len(endpointStatuses))
+	for _, ep := range endpointStatuses {
+		endpoints = append(endpoints, EndpointStatus{
+			Host:                ep.Host,
+			Port:                ep.Port,
+			Region:              ep.Region,
+			Healthy:             ep.Healthy,
+			Inflight:            ep.Inflight,
+			Dispatched:          ep.Dispatched,
+			ConsecutiveFailures: ep.ConsecutiveFailures,
+		})
+	}
+
+	geoCache := h.core.GeoCacheStats()
+
+	var geoIPStatus *GeoIPStatus
+	if status, ok := h.core.GeoIPStatus(); ok {
+		geoIPStatus = &GeoIPStatus{BuildDate: status.BuildDate, LastRefresh: status.LastRefresh}
+	}
+
+	activeVersion, err := h.versionStore.ActiveVersion(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to read active config version", zap.Error(err))
+	}
+
 	writeJSON(w, HealthResponse{
 		Status:  "ok",
 		Version: h.versionInfo.Version,
@@ -256,47 +575,27 @@ func (h *Handler) handleHealth(w http.ResponseWriter, r *http.Request) {
 			QueueLength:    stats.QueueLength,
 			IsRunning:      stats.IsRunning,
 			Uptime:         stats.Uptime.String(),
+			Endpoints:      endpoints,
+		},
+		GeoCache: GeoCacheStatus{
+			Entries: geoCache.Entries,
+			Hits:    geoCache.Hits,
+			Misses:  geoCache.Misses,
 		},
+		GeoIP:         geoIPStatus,
+		ActiveVersion: activeVersion,
 	})
 }
 
-func (h *Handler) flushRedisCache() error {
-	const (
-		pattern   = "config:*"
-		batchSize = 1000
-	)
-
-	ctx := context.Background()
-	iter := h.redis.Scan(ctx, 0, pattern, 0).Iterator()
-	pipe := h.redis.Pipeline()
-
-	batch := make([]string, 0, batchSize)
-	for iter.Next(ctx) {
-		batch = append(batch, iter.Val())
-
-		if len(batch) == batchSize {
-			if err := pipe.Del(ctx, batch...).Err(); err != nil {
-				return fmt.Errorf("failed to delete batch from Redis: %w", err)
-			}
-			batch = batch[:0]
-		}
-	}
-
-	if len(batch) > 0 {
-		if err := pipe.Del(ctx, batch...).Err(); err != nil {
-			return fmt.Errorf("failed to delete remaining keys from Redis: %w", err)
-		}
-	}
-
-	if err := iter.Err(); err != nil {
-		return fmt.Errorf("failed to iterate Redis keys: %w", err)
-	}
-
-	if _, err := pipe.Exec(ctx); err != nil {
-		return fmt.Errorf("failed to execute Redis pipeline: %w", err)
+// handleGeoIPRefresh triggers an on-demand MaxMind download and hot-reload
+// of the GeoIP database, bypassing the configured refresh TTL. It 404s when
+// no GeoIPManager is configured (GEOIP_LICENSE_KEY unset).
+func (h *Handler) handleGeoIPRefresh(w http.ResponseWriter, r *http.Request) {
+	if err := h.core.RefreshGeoIP(r.Context()); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
 	}
-
-	return nil
+	writeJSON(w, MessageResponse{Status: http.StatusOK, Message: "geoip database refreshed"})
 }
 
 func (h *Handler) executeCheckTask(ctx context.Context, data interface{}) (interface{}, error) {
@@ -335,9 +634,16 @@ func (h *Handler) executeCheckTask(ctx context.Context, data interface{}) (inter
 				zap.String("protocol", result.Protocol),
 				zap.Int64("delay_ms", result.RealDelay.Milliseconds()))
 
-			job.AddResult(HashConfig(result.Raw), checkResult)
+			configHash := HashConfig(result.Raw)
+			job.AddResult(configHash, checkResult)
+			if err := h.jobStore.AppendResult(ctx, job.ID, configHash, checkResult); err != nil {
+				h.logger.Error("Failed to append job result to store", zap.String("job_id", job.ID), zap.Error(err))
+			}
 			if !strings.HasPrefix(job.ID, "refresh-") {
 				h.jobsOnSuccess(result)
+				if h.notifyRegistry != nil {
+					h.notifyRegistry.Dispatch(result)
+				}
 			}
 		}
 
@@ -386,40 +692,6 @@ func (h *Handler) handleTaskResult(callback CallbackHandler) func(workerpool.Res
 	}
 }
 
-func (h *Handler) filterDuplicates(configs []string) ([]string, error) {
-	ctx := context.Background()
-	uniqueConfigs := make([]string, 0, len(configs))
-	pipe := h.redis.Pipeline()
-	cmds := make([]*redis.IntCmd, len(configs))
-	hashes := make([]string, len(configs))
-
-	for i, config := range configs {
-		hash := HashConfig(config)
-		hashes[i] = hash
-		cmds[i] = pipe.Exists(ctx, "config:"+hash)
-	}
-
-	if _, err := pipe.Exec(ctx); err != nil {
-		h.logger.Error("Failed to filter duplicates", zap.Error(err))
-		return nil, err
-	}
-
-	pipe = h.redis.Pipeline()
-	for i, cmd := range cmds {
-		if cmd.Val() == 0 {
-			uniqueConfigs = append(uniqueConfigs, configs[i])
-			pipe.Set(ctx, "config:"+hashes[i], "1", h.redisResultExpiration)
-		}
-	}
-
-	_, err := pipe.Exec(ctx)
-	if err != nil {
-		h.logger.Error("Failed to filter duplicates", zap.Error(err))
-		return nil, err
-	}
-	return uniqueConfigs, err
-}
-
 func (h *Handler) Close() {
 	h.workerPool.Stop()
 }
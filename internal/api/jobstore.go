@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	jobStoreKeyPrefix     = "job:"
+	jobStoreIndexKey      = "jobs:index"
+	jobStoreResultsSuffix = ":results"
+)
+
+func jobKey(id string) string        { return jobStoreKeyPrefix + id }
+func jobResultsKey(id string) string { return jobStoreKeyPrefix + id + jobStoreResultsSuffix }
+
+// JobStore persists Job metadata to a Redis hash (plus a sorted-set index
+// for listing) and per-config outcomes to a Redis Stream, so an API
+// restart doesn't lose track of in-flight or recently finished scans the
+// way the process-local Handler.jobs sync.Map does.
+type JobStore struct {
+	redis redis.UniversalClient
+	ttl   time.Duration
+}
+
+// NewJobStore returns a store that expires job records after ttl (0 keeps
+// them forever).
+func NewJobStore(redisClient redis.UniversalClient, ttl time.Duration) *JobStore {
+	return &JobStore{redis: redisClient, ttl: ttl}
+}
+
+// hashConfigs fingerprints a job's config list so two jobs submitted with
+// the same batch can be recognized as duplicates later.
+func hashConfigs(configs []string) string {
+	h := sha256.New()
+	for _, c := range configs {
+		h.Write([]byte(c))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Save write-throughs job's current state to Redis. It is safe to call on
+// every Job mutation; the hash is small and overwritten wholesale.
+func (s *JobStore) Save(ctx context.Context, job *Job) error {
+	job.mutex.RLock()
+	fields := map[string]interface{}{
+		"id":           job.ID,
+		"state":        string(job.Status),
+		"total":        job.TotalCount,
+		"done":         job.DoneCount,
+		"created_at":   job.CreatedAt.Format(time.RFC3339Nano),
+		"configs_hash": hashConfigs(job.Configs),
+		"error":        job.Error,
+	}
+	if job.StartTime != nil {
+		fields["started_at"] = job.StartTime.Format(time.RFC3339Nano)
+	}
+	if job.EndTime != nil {
+		fields["ended_at"] = job.EndTime.Format(time.RFC3339Nano)
+	}
+	configsJSON, err := json.Marshal(job.Configs)
+	createdAtUnix := job.CreatedAt.Unix()
+	id := job.ID
+	job.mutex.RUnlock()
+	if err != nil {
+		return fmt.Errorf("marshal job %s configs: %w", id, err)
+	}
+	fields["configs"] = string(configsJSON)
+
+	pipe := s.redis.TxPipeline()
+	pipe.HSet(ctx, jobKey(id), fields)
+	if s.ttl > 0 {
+		pipe.Expire(ctx, jobKey(id), s.ttl)
+	}
+	pipe.ZAdd(ctx, jobStoreIndexKey, redis.Z{Score: float64(createdAtUnix), Member: id})
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("save job %s: %w", id, err)
+	}
+	return nil
+}
+
+// Load reconstructs a Job from its Redis hash. The returned Job has no
+// OnUpdate publisher wired up; callers that want live updates must call
+// trackJob on it.
+func (s *JobStore) Load(ctx context.Context, id string) (*Job, error) {
+	data, err := s.redis.HGetAll(ctx, jobKey(id)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("load job %s: %w", id, err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("job %s not found", id)
+	}
+
+	var configs []string
+	if raw, ok := data["configs"]; ok && raw != "" {
+		if err := json.Unmarshal([]byte(raw), &configs); err != nil {
+			return nil, fmt.Errorf("unmarshal job %s configs: %w", id, err)
+		}
+	}
+
+	job := NewJob(configs)
+	job.ID = id
+	job.Status = JobStatus(data["state"])
+	job.TotalCount, _ = strconv.Atoi(data["total"])
+	job.DoneCount, _ = strconv.Atoi(data["done"])
+	job.Error = data["error"]
+	if raw := data["created_at"]; raw != "" {
+		if t, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+			job.CreatedAt = t
+		}
+	}
+	if raw := data["started_at"]; raw != "" {
+		if t, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+			job.StartTime = &t
+		}
+	}
+	if raw := data["ended_at"]; raw != "" {
+		if t, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+			job.EndTime = &t
+		}
+	}
+
+	return job, nil
+}
+
+// List returns up to limit job summaries, most-recently-created first,
+// skipping the first offset — the backing for a paginated GET /jobs.
+func (s *JobStore) List(ctx context.Context, offset, limit int) ([]*Job, error) {
+	ids, err := s.redis.ZRevRange(ctx, jobStoreIndexKey, int64(offset), int64(offset+limit-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list jobs: %w", err)
+	}
+
+	jobs := make([]*Job, 0, len(ids))
+	for _, id := range ids {
+		job, err := s.Load(ctx, id)
+		if err != nil {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// ListRunning returns every job still recorded as running, so Handler can
+// reconcile them against live worker state after a restart.
+func (s *JobStore) ListRunning(ctx context.Context) ([]*Job, error) {
+	ids, err := s.redis.ZRevRange(ctx, jobStoreIndexKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list running jobs: %w", err)
+	}
+
+	var running []*Job
+	for _, id := range ids {
+		job, err := s.Load(ctx, id)
+		if err != nil {
+			continue
+		}
+		if job.Status == JobStatusRunning {
+			running = append(running, job)
+		}
+	}
+	return running, nil
+}
+
+// AppendResult records one config's outcome on the job's Redis Stream, in
+// addition to the summary counters Save tracks on the job hash.
+func (s *JobStore) AppendResult(ctx context.Context, jobID, configHash string, result CheckResult) error {
+	if err := s.redis.XAdd(ctx, &redis.XAddArgs{
+		Stream: jobResultsKey(jobID),
+		Values: map[string]interface{}{
+			"config_hash": configHash,
+			"status":      result.Status,
+			"delay_ms":    result.Delay,
+			"error":       result.Error,
+		},
+	}).Err(); err != nil {
+		return fmt.Errorf("append result for job %s: %w", jobID, err)
+	}
+	if s.ttl > 0 {
+		s.redis.Expire(ctx, jobResultsKey(jobID), s.ttl)
+	}
+	return nil
+}
+
+// ReadResults returns every per-config result appended to job's stream
+// strictly after lastID (a Redis Stream ID, e.g. from a client's
+// Last-Event-ID header), oldest first. It backs GET /jobs/{id}/stream's
+// backlog replay, which works the same regardless of which API replica
+// appended the results or is serving the replay.
+func (s *JobStore) ReadResults(ctx context.Context, jobID, lastID string) ([]redis.XMessage, error) {
+	msgs, err := s.redis.XRange(ctx, jobResultsKey(jobID), "("+lastID, "+").Result()
+	if err != nil {
+		return nil, fmt.Errorf("read results for job %s: %w", jobID, err)
+	}
+	return msgs, nil
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	jobChannelPrefix  = "namira:job:"
+	jobSnapshotPrefix = "namira:job-snapshot:"
+	jobSnapshotTTL    = 10 * time.Minute
+	resubscribeDelay  = time.Second
+)
+
+// KeyWatcher multiplexes Redis pub/sub notifications for job progress over a
+// single subscription per job ID, analogous to Workhorse's goredis/keywatcher.
+// Any number of local subscribers (SSE handlers) share that one connection.
+type KeyWatcher struct {
+	redis  redis.UniversalClient
+	logger *zap.Logger
+
+	mu   sync.Mutex
+	jobs map[string]*jobWatch
+}
+
+type jobWatch struct {
+	subscribers map[chan JobEvent]struct{}
+	cancel      context.CancelFunc
+}
+
+func NewKeyWatcher(redisClient redis.UniversalClient, logger *zap.Logger) *KeyWatcher {
+	return &KeyWatcher{
+		redis:  redisClient,
+		logger: logger,
+		jobs:   make(map[string]*jobWatch),
+	}
+}
+
+// Publish stores the latest snapshot and notifies subscribers via Redis pub/sub.
+func (kw *KeyWatcher) Publish(ctx context.Context, event JobEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job event: %w", err)
+	}
+
+	if err := kw.redis.Set(ctx, jobSnapshotPrefix+event.JobID, data, jobSnapshotTTL).Err(); err != nil {
+		return fmt.Errorf("failed to store job snapshot: %w", err)
+	}
+
+	return kw.redis.Publish(ctx, jobChannelPrefix+event.JobID, data).Err()
+}
+
+// Subscribe returns a channel of job events for jobID and an unsubscribe
+// function the caller must invoke exactly once. The underlying Redis
+// subscription is shared and refcounted across all subscribers of a job.
+func (kw *KeyWatcher) Subscribe(jobID string) (<-chan JobEvent, func()) {
+	kw.mu.Lock()
+	watch, exists := kw.jobs[jobID]
+	if !exists {
+		ctx, cancel := context.WithCancel(context.Background())
+		watch = &jobWatch{
+			subscribers: make(map[chan JobEvent]struct{}),
+			cancel:      cancel,
+		}
+		kw.jobs[jobID] = watch
+		go kw.watch(ctx, jobID, watch)
+	}
+
+	ch := make(chan JobEvent, 16)
+	watch.subscribers[ch] = struct{}{}
+	kw.mu.Unlock()
+
+	unsubscribe := func() {
+		kw.mu.Lock()
+		defer kw.mu.Unlock()
+
+		delete(watch.subscribers, ch)
+		close(ch)
+
+		if len(watch.subscribers) == 0 {
+			watch.cancel()
+			delete(kw.jobs, jobID)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+func (kw *KeyWatcher) broadcast(watch *jobWatch, event JobEvent) {
+	kw.mu.Lock()
+	defer kw.mu.Unlock()
+
+	for ch := range watch.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Slow subscriber: drop the update rather than block the fan-out.
+		}
+	}
+}
+
+// watch owns a single Redis subscription for jobID. It resends the latest
+// snapshot to every subscriber on (re)connect, so a dropped Redis connection
+// never loses progress, only the deltas in between.
+func (kw *KeyWatcher) watch(ctx context.Context, jobID string, watch *jobWatch) {
+	channel := jobChannelPrefix + jobID
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if event, err := kw.snapshot(ctx, jobID); err == nil {
+			kw.broadcast(watch, event)
+		}
+
+		pubsub := kw.redis.Subscribe(ctx, channel)
+		msgs := pubsub.Channel()
+
+	receive:
+		for {
+			select {
+			case <-ctx.Done():
+				pubsub.Close()
+				return
+			case msg, ok := <-msgs:
+				if !ok {
+					break receive
+				}
+				var event JobEvent
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					kw.logger.Warn("Failed to decode job event", zap.Error(err))
+					continue
+				}
+				kw.broadcast(watch, event)
+				if event.Status == JobStatusCompleted || event.Status == JobStatusFailed {
+					pubsub.Close()
+					return
+				}
+			}
+		}
+
+		pubsub.Close()
+		kw.logger.Warn("Job event subscription dropped, resubscribing", zap.String("job_id", jobID))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+func (kw *KeyWatcher) snapshot(ctx context.Context, jobID string) (JobEvent, error) {
+	data, err := kw.redis.Get(ctx, jobSnapshotPrefix+jobID).Bytes()
+	if err != nil {
+		return JobEvent{}, err
+	}
+
+	var event JobEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return JobEvent{}, err
+	}
+	return event, nil
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitConfig configures the per-IP limiter installed on rate-limited routes.
+type RateLimitConfig struct {
+	RPS     float64
+	Burst   int
+	LRUSize int
+}
+
+// ipRateLimiter hands out one rate.Limiter per client IP, bounded to maxSize
+// entries via an LRU so a flood of distinct IPs can't grow memory unbounded.
+type ipRateLimiter struct {
+	mu      sync.Mutex
+	rps     rate.Limit
+	burst   int
+	maxSize int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type limiterEntry struct {
+	ip      string
+	limiter *rate.Limiter
+}
+
+func newIPRateLimiter(cfg RateLimitConfig) *ipRateLimiter {
+	return &ipRateLimiter{
+		rps:     rate.Limit(cfg.RPS),
+		burst:   cfg.Burst,
+		maxSize: cfg.LRUSize,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (l *ipRateLimiter) allow(ip string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if el, ok := l.entries[ip]; ok {
+		l.order.MoveToFront(el)
+		return el.Value.(*limiterEntry).limiter.Allow()
+	}
+
+	limiter := rate.NewLimiter(l.rps, l.burst)
+	el := l.order.PushFront(&limiterEntry{ip: ip, limiter: limiter})
+	l.entries[ip] = el
+
+	if l.maxSize > 0 && l.order.Len() > l.maxSize {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*limiterEntry).ip)
+	}
+
+	return limiter.Allow()
+}
+
+// RateLimitMiddleware throttles requests per client IP (as resolved by
+// ClientIPMiddleware), returning 429 once the IP's budget is exhausted.
+func RateLimitMiddleware(cfg RateLimitConfig) func(http.Handler) http.Handler {
+	limiter := newIPRateLimiter(cfg)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !limiter.allow(ClientIP(r)) {
+				writeError(w, "Too many requests", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
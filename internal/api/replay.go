@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// scanReplayKeyPrefix namespaces a captured scan's Redis key, suffixed
+	// with the job ID it produced.
+	scanReplayKeyPrefix = "scan_replay:"
+
+	// scanReplayRingKey holds the most recent scanReplayRingSize job IDs, so
+	// recent captures can be enumerated without scanning every key.
+	scanReplayRingKey  = "scan_replay:ring"
+	scanReplayRingSize = 200
+
+	scanReplayTTL = 24 * time.Hour
+)
+
+// CapturedScan is one /scan POST, preserved so it can be replayed verbatim
+// against a later build.
+type CapturedScan struct {
+	JobID      string      `json:"job_id"`
+	Method     string      `json:"method"`
+	Path       string      `json:"path"`
+	Headers    http.Header `json:"headers"`
+	Body       []byte      `json:"body"`
+	CapturedAt time.Time   `json:"captured_at"`
+}
+
+// ScanReplayStore persists captured /scan requests in Redis under a
+// size-bounded ring buffer, mirroring the request-reproducer pattern from
+// the frostfs-s3-gw project: every capture is keyed by the job ID it
+// produced, so operators can replay the exact payload behind a failing scan
+// without reconstructing it by hand.
+type ScanReplayStore struct {
+	redis redis.UniversalClient
+}
+
+// NewScanReplayStore returns a ScanReplayStore backed by redisClient.
+func NewScanReplayStore(redisClient redis.UniversalClient) *ScanReplayStore {
+	return &ScanReplayStore{redis: redisClient}
+}
+
+// Capture persists r (headers + body) under jobID, and pushes jobID onto the
+// bounded ring buffer so the most recent captures evict the oldest ones.
+func (s *ScanReplayStore) Capture(ctx context.Context, r *http.Request, body []byte, jobID string) error {
+	captured := CapturedScan{
+		JobID:      jobID,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		Headers:    r.Header.Clone(),
+		Body:       body,
+		CapturedAt: time.Now(),
+	}
+
+	data, err := json.Marshal(captured)
+	if err != nil {
+		return fmt.Errorf("marshal captured scan: %w", err)
+	}
+
+	pipe := s.redis.Pipeline()
+	pipe.Set(ctx, scanReplayKeyPrefix+jobID, data, scanReplayTTL)
+	pipe.LPush(ctx, scanReplayRingKey, jobID)
+	pipe.LTrim(ctx, scanReplayRingKey, 0, scanReplayRingSize-1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("persist captured scan: %w", err)
+	}
+	return nil
+}
+
+// Get loads the scan captured under jobID, or redis.Nil if it has expired or
+// was never captured.
+func (s *ScanReplayStore) Get(ctx context.Context, jobID string) (*CapturedScan, error) {
+	data, err := s.redis.Get(ctx, scanReplayKeyPrefix+jobID).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var captured CapturedScan
+	if err := json.Unmarshal(data, &captured); err != nil {
+		return nil, fmt.Errorf("unmarshal captured scan: %w", err)
+	}
+	return &captured, nil
+}
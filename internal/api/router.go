@@ -1,28 +1,45 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
 	"net/http"
 	"os"
 	"time"
 
-	"github.com/NamiraNet/namira-core/internal/core"
-	"github.com/NamiraNet/namira-core/internal/github"
-	"github.com/NamiraNet/namira-core/internal/logger"
-	workerpool "github.com/NamiraNet/namira-core/internal/worker"
-	"github.com/go-redis/redis/v8"
+	"github.com/NaMiraNet/namira-core/internal/core"
+	"github.com/NaMiraNet/namira-core/internal/faultproxy"
+	"github.com/NaMiraNet/namira-core/internal/github"
+	"github.com/NaMiraNet/namira-core/internal/logger"
+	"github.com/NaMiraNet/namira-core/internal/metrics"
+	"github.com/NaMiraNet/namira-core/internal/notify"
+	workerpool "github.com/NaMiraNet/namira-core/internal/worker"
 	"github.com/gorilla/mux"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
-func NewRouter(c *core.Core, redisClient *redis.Client, callbackHandler CallbackHandler, configSuccessHandler ConfigSuccessHandler, logger *zap.Logger, updater *github.Updater, worker *workerpool.WorkerPool, versionInfo VersionInfo, redisResultExpiration time.Duration, refreshInterval time.Duration) *mux.Router {
+func NewRouter(c *core.Core, redisClient redis.UniversalClient, callbackHandler CallbackHandler, configSuccessHandler ConfigSuccessHandler, logger *zap.Logger, updater *github.Updater, worker *workerpool.WorkerPool, versionInfo VersionInfo, redisResultExpiration time.Duration, refreshInterval time.Duration, trustedProxies []string, rateLimit RateLimitConfig, faultRegistry *faultproxy.Registry, debugFaultsEnabled bool, metricsRegistry *metrics.Registry, notifyRegistry *notify.NotifierRegistry) *mux.Router {
 	r := mux.NewRouter()
-	h := NewHandler(c, redisClient, callbackHandler, configSuccessHandler, logger, updater, worker, versionInfo, redisResultExpiration, refreshInterval)
+	replayStore := NewScanReplayStore(redisClient)
+	h := NewHandler(c, redisClient, callbackHandler, configSuccessHandler, logger, updater, worker, versionInfo, redisResultExpiration, refreshInterval, faultRegistry, replayStore, notifyRegistry)
 
-	r.Use(corsMiddleware, authMiddleware, loggingMiddleware)
+	r.Use(corsMiddleware, ClientIPMiddleware(trustedProxies), authMiddleware, loggingMiddleware(replayStore))
 
-	r.HandleFunc("/scan", h.handleScan).Methods(http.MethodPost)
+	r.Handle("/scan", RateLimitMiddleware(rateLimit)(http.HandlerFunc(h.handleScan))).Methods(http.MethodPost)
+	r.HandleFunc("/scan/{id}/replay", h.handleScanReplay).Methods(http.MethodGet)
 	r.HandleFunc("/job/{id}", h.handleJobStatus).Methods(http.MethodGet)
+	r.HandleFunc("/jobs", h.handleJobsList).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/{id}/stream", h.handleJobStream).Methods(http.MethodGet)
 	r.HandleFunc("/health", h.handleHealth).Methods(http.MethodGet)
+	r.HandleFunc("/admin/geoip/refresh", h.handleGeoIPRefresh).Methods(http.MethodPost)
+	r.Handle("/metrics", metricsRegistry.Handler()).Methods(http.MethodGet)
+
+	if debugFaultsEnabled {
+		r.HandleFunc("/debug/faults", h.handleDebugFaults).Methods(http.MethodGet, http.MethodPost)
+	}
 
 	return r
 }
@@ -42,21 +59,77 @@ func corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-func loggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		logger.Info("request received",
-			zap.String("method", r.Method),
-			zap.String("path", r.URL.Path),
-			zap.String("remote_addr", r.RemoteAddr),
-		)
-		next.ServeHTTP(w, r)
-	})
+// loggingMiddleware logs every request and, for POST /scan, also persists
+// the request into replay so it can be re-enqueued later via
+// GET /scan/{id}/replay. A nil replay disables capture entirely.
+func loggingMiddleware(replay *ScanReplayStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger.Info("request received",
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.String("remote_addr", r.RemoteAddr),
+				zap.String("client_ip", ClientIP(r)),
+			)
+
+			if replay == nil || r.Method != http.MethodPost || r.URL.Path != "/scan" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			r.Body.Close()
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			capture := &responseCapture{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(capture, r)
+
+			if capture.status != http.StatusOK {
+				return
+			}
+
+			var resp ScanResponse
+			if err := json.Unmarshal(capture.body, &resp); err != nil || resp.JobID == "" {
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := replay.Capture(ctx, r, body, resp.JobID); err != nil {
+				logger.Error("failed to capture scan for replay", zap.Error(err))
+			}
+		})
+	}
+}
+
+// responseCapture buffers a handler's status code and body alongside
+// forwarding them to the real ResponseWriter, so loggingMiddleware can
+// inspect the outcome (the job ID from a successful /scan) without changing
+// what the client receives.
+type responseCapture struct {
+	http.ResponseWriter
+	status int
+	body   []byte
+}
+
+func (rc *responseCapture) WriteHeader(status int) {
+	rc.status = status
+	rc.ResponseWriter.WriteHeader(status)
+}
+
+func (rc *responseCapture) Write(b []byte) (int, error) {
+	rc.body = append(rc.body, b...)
+	return rc.ResponseWriter.Write(b)
 }
 
 func authMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Skip auth for health check endpoint
-		if r.URL.Path == "/health" {
+		// Skip auth for health check and metrics scrape endpoints
+		if r.URL.Path == "/health" || r.URL.Path == "/metrics" {
 			next.ServeHTTP(w, r)
 			return
 		}
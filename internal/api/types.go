@@ -31,6 +31,63 @@ type Job struct {
 	CreatedAt  time.Time              `json:"created_at"`
 	Error      string                 `json:"error,omitempty"`
 	mutex      sync.RWMutex           `json:"-"`
+	onUpdate   JobEventPublisher      `json:"-"`
+}
+
+// JobEvent is the payload published to Redis (and streamed over SSE)
+// whenever a Job's progress or status changes. ConfigHash/Result are set
+// when the event was triggered by a single config finishing rather than a
+// status transition, so GET /jobs/{id}/stream can report both coarse
+// progress and per-config outcomes over the same Redis-backed channel.
+type JobEvent struct {
+	JobID      string       `json:"job_id"`
+	Status     JobStatus    `json:"status"`
+	Done       int          `json:"done"`
+	Total      int          `json:"total"`
+	LastIndex  int          `json:"last_index"`
+	ConfigHash string       `json:"config_hash,omitempty"`
+	Result     *CheckResult `json:"result,omitempty"`
+	Error      string       `json:"error,omitempty"`
+}
+
+// JobEventPublisher is notified after every Job mutation. Handler wires this
+// to a KeyWatcher so progress fans out over Redis pub/sub instead of only
+// living in the process that owns the Job.
+type JobEventPublisher func(JobEvent)
+
+// OnUpdate registers the publisher invoked after each mutation. It must be
+// called before the job is mutated concurrently.
+func (j *Job) OnUpdate(publisher JobEventPublisher) {
+	j.mutex.Lock()
+	j.onUpdate = publisher
+	j.mutex.Unlock()
+}
+
+// snapshotEvent builds a JobEvent from the job's current state. Callers must
+// hold j.mutex. configHash/result are set when the event represents a
+// single config finishing, left zero-valued for a status transition.
+func (j *Job) snapshotEvent(lastIndex int, configHash string, result *CheckResult) JobEvent {
+	return JobEvent{
+		JobID:      j.ID,
+		Status:     j.Status,
+		Done:       j.DoneCount,
+		Total:      j.TotalCount,
+		LastIndex:  lastIndex,
+		ConfigHash: configHash,
+		Result:     result,
+		Error:      j.Error,
+	}
+}
+
+func (j *Job) publish(lastIndex int, configHash string, result *CheckResult) {
+	j.mutex.RLock()
+	onUpdate := j.onUpdate
+	event := j.snapshotEvent(lastIndex, configHash, result)
+	j.mutex.RUnlock()
+
+	if onUpdate != nil {
+		onUpdate(event)
+	}
 }
 
 type TaskData struct {
@@ -68,19 +125,49 @@ type CheckResult struct {
 }
 
 type WorkerPoolStatus struct {
-	WorkerCount    int    `json:"worker_count"`
-	TotalTasks     int64  `json:"total_tasks"`
-	CompletedTasks int64  `json:"completed_tasks"`
-	FailedTasks    int64  `json:"failed_tasks"`
-	QueueLength    int64  `json:"queue_length"`
-	IsRunning      bool   `json:"is_running"`
-	Uptime         string `json:"uptime"`
+	WorkerCount    int              `json:"worker_count"`
+	TotalTasks     int64            `json:"total_tasks"`
+	CompletedTasks int64            `json:"completed_tasks"`
+	FailedTasks    int64            `json:"failed_tasks"`
+	QueueLength    int64            `json:"queue_length"`
+	IsRunning      bool             `json:"is_running"`
+	Uptime         string           `json:"uptime"`
+	Endpoints      []EndpointStatus `json:"endpoints,omitempty"`
+}
+
+// EndpointStatus reports live scheduling stats for one check-server endpoint.
+type EndpointStatus struct {
+	Host                string `json:"host"`
+	Port                uint32 `json:"port"`
+	Region              string `json:"region,omitempty"`
+	Healthy             bool   `json:"healthy"`
+	Inflight            int    `json:"inflight"`
+	Dispatched          int64  `json:"dispatched"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
 }
 
 type HealthResponse struct {
-	Status     string           `json:"status"`
-	Version    string           `json:"version"`
-	WorkerPool WorkerPoolStatus `json:"worker_pool"`
+	Status        string           `json:"status"`
+	Version       string           `json:"version"`
+	WorkerPool    WorkerPoolStatus `json:"worker_pool"`
+	GeoCache      GeoCacheStatus   `json:"geo_cache"`
+	GeoIP         *GeoIPStatus     `json:"geoip,omitempty"`
+	ActiveVersion int64            `json:"active_version"`
+}
+
+// GeoCacheStatus reports the active GeoIP resolver's lookup cache.
+type GeoCacheStatus struct {
+	Entries int   `json:"entries"`
+	Hits    int64 `json:"hits"`
+	Misses  int64 `json:"misses"`
+}
+
+// GeoIPStatus reports the active GeoIPManager's database build date and
+// last refresh time. Omitted from HealthResponse entirely when no
+// GeoIPManager is configured (GeoIPLicenseKey unset).
+type GeoIPStatus struct {
+	BuildDate   time.Time `json:"build_date,omitempty"`
+	LastRefresh time.Time `json:"last_refresh,omitempty"`
 }
 
 func NewJob(configs []string) *Job {
@@ -96,8 +183,6 @@ func NewJob(configs []string) *Job {
 
 func (j *Job) updateStatus(status JobStatus, err error) {
 	j.mutex.Lock()
-	defer j.mutex.Unlock()
-
 	now := time.Now()
 	j.Status = status
 	if status != JobStatusRunning {
@@ -108,6 +193,9 @@ func (j *Job) updateStatus(status JobStatus, err error) {
 	if err != nil {
 		j.Error = err.Error()
 	}
+	j.mutex.Unlock()
+
+	j.publish(-1, "", nil)
 }
 
 func (j *Job) Start() {
@@ -125,14 +213,20 @@ func (j *Job) Fail(err error) {
 func (j *Job) Done() {
 	j.mutex.Lock()
 	j.DoneCount++
+	index := j.DoneCount - 1
 	j.mutex.Unlock()
+
+	j.publish(index, "", nil)
 }
 
 func (j *Job) AddResult(configHash string, result CheckResult) {
 	j.mutex.Lock()
 	j.Results[configHash] = result
 	j.DoneCount++
+	index := j.DoneCount - 1
 	j.mutex.Unlock()
+
+	j.publish(index, configHash, &result)
 }
 
 func HashConfig(config string) string {
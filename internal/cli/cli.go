@@ -8,7 +8,8 @@ import (
 	"strings"
 	"time"
 
-	"github.com/NamiraNet/namira-core/internal/core"
+	"github.com/NaMiraNet/namira-core/internal/core"
+	"github.com/NaMiraNet/namira-core/internal/export"
 )
 
 type CLI struct {
@@ -189,6 +190,10 @@ func (om *OutputManager) Output(results []core.CheckResult, options OutputOption
 		output, err = om.CSV(results)
 	case "table":
 		output = om.Table(results)
+	case string(export.FormatBase64), string(export.FormatClash), string(export.FormatSingBox):
+		var data []byte
+		data, err = export.Render(results, export.Format(options.Format))
+		output = string(data)
 	default:
 		return fmt.Errorf("unsupported output format: %s", options.Format)
 	}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	_ "github.com/joho/godotenv/autoload"
@@ -10,12 +12,13 @@ import (
 
 // Config holds the base configuration
 type Config struct {
-	Server   ServerConfig
-	Worker   WorkerConfig
-	Redis    RedisConfig
-	App      AppConfig
-	Github   GithubConfig
-	Telegram TelegramConfig
+	Server    ServerConfig
+	Worker    WorkerConfig
+	Redis     RedisConfig
+	App       AppConfig
+	Github    GithubConfig
+	Telegram  TelegramConfig
+	RateLimit RateLimitConfig
 }
 
 type ServerConfig struct {
@@ -24,6 +27,26 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+
+	// TrustedProxies lists CIDRs (reverse proxies/load balancers) allowed to
+	// set X-Forwarded-For/X-Real-IP. Empty means RemoteAddr is trusted as-is.
+	TrustedProxies []string
+
+	// GRPCPort, when non-empty, starts the commander gRPC API (see
+	// internal/api/grpc) on its own listener alongside the HTTP API.
+	GRPCPort string
+
+	// MetricsPort, when non-empty, serves /metrics on its own listener in
+	// addition to the main router's /metrics route - useful when the main
+	// API is behind auth/network policy that a Prometheus scraper can't see.
+	MetricsPort string
+}
+
+// RateLimitConfig configures the per-client-IP limiter applied to rate-limited routes.
+type RateLimitConfig struct {
+	RPS     float64
+	Burst   int
+	LRUSize int
 }
 
 type WorkerConfig struct {
@@ -31,23 +54,140 @@ type WorkerConfig struct {
 	QueueSize int
 }
 
+// RedisMode selects the topology used to reach the Redis deployment.
+type RedisMode string
+
+const (
+	RedisModeSingle   RedisMode = "single"
+	RedisModeSentinel RedisMode = "sentinel"
+	RedisModeCluster  RedisMode = "cluster"
+)
+
+type RedisTLSConfig struct {
+	Enabled            bool
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	InsecureSkipVerify bool
+}
+
 type RedisConfig struct {
+	Mode     RedisMode
 	Addr     string
 	Password string
 	DB       int
+
+	// Sentinel mode
+	SentinelAddrs    []string
+	SentinelMaster   string
+	SentinelPassword string
+
+	// Cluster mode
+	ClusterAddrs   []string
+	RouteByLatency bool
+	RouteRandomly  bool
+
+	TLS RedisTLSConfig
 }
 
 type GithubConfig struct {
 	SSHKeyPath string
 	Owner      string
 	Repo       string
+
+	// StorageBackend selects the github.ResultStore implementation: "git"
+	// (default, the original SSH clone/push + raw.githubusercontent
+	// fallback), "s3", "fs", or "redis".
+	StorageBackend string
+
+	// S3-compatible object storage (StorageBackend == "s3").
+	S3Endpoint  string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3Region    string
+	S3UseSSL    bool
+
+	// FSPath is the results.txt path on disk (StorageBackend == "fs").
+	FSPath string
+
+	// RedisKey is the key the encrypted blob is stored under
+	// (StorageBackend == "redis").
+	RedisKey string
+
+	// FlushInterval is how often github.Updater coalesces buffered
+	// ProcessScanResults deltas (see internal/github/publisher.go) into a
+	// single store.Put.
+	FlushInterval time.Duration
 }
 
 type AppConfig struct {
 	LogLevel      string
 	Timeout       time.Duration
 	MaxConcurrent int
+
+	// EncryptionKey is the legacy single-key secret. It still works
+	// unchanged for deployments that haven't adopted EncryptionKeys, and it
+	// is also where legacy (pre-keyring) results.txt files are decrypted
+	// from if EncryptionKeys is unset.
 	EncryptionKey string
+
+	// EncryptionKeys is a crypto.ParseKeyring spec ("id:hex,id:hex,...") for
+	// key rotation; EncryptionKeyActive names the id new content is
+	// encrypted under. When empty, EncryptionKey alone is used as a
+	// single-entry keyring under id "default".
+	EncryptionKeys      string
+	EncryptionKeyActive string
+
+	// EncryptionEnvelopeMode, when true, encrypts new content under a fresh
+	// per-write data key wrapped by the active keyring key (DEK/KEK), so a
+	// job's data key can be escrowed or revoked without re-encrypting
+	// everything else.
+	EncryptionEnvelopeMode bool
+
+	// ResultsCompression picks the codec results.txt content is compressed
+	// with before encryption: "zstd" (default), "gzip", or "none".
+	ResultsCompression string
+
+	// CheckEndpoints lists check-server pool entries as
+	// "host:port:weight:region:maxInflight" (weight/region/maxInflight
+	// optional). Empty means a single endpoint built from CheckHost is used.
+	CheckEndpoints []string
+
+	// DebugFaultsEnabled mounts the admin-only POST/GET /debug/faults
+	// endpoint for installing faultproxy rules at runtime. Leave off in
+	// production; it is meant for chaos-testing the check-server pool.
+	DebugFaultsEnabled bool
+
+	// GeoIPDBPath is the path to a GeoLite2-Country.mmdb; empty uses
+	// geo.DefaultDBPath. Overridable by the --geoip-db CLI flag.
+	GeoIPDBPath string
+
+	// GeoIPAccountID/GeoIPLicenseKey authenticate against MaxMind's download
+	// API. When GeoIPLicenseKey is set, a geo.GeoIPManager downloads
+	// GeoIPDBPath on startup if it's missing or older than
+	// GeoIPRefreshInterval, verifies it against MaxMind's SHA256 sidecar, and
+	// refreshes it on that interval; empty disables automatic management and
+	// GeoIPDBPath is opened as-is.
+	GeoIPAccountID       string
+	GeoIPLicenseKey      string
+	GeoIPRefreshInterval time.Duration
+
+	// GeoAllowCountries/GeoDenyCountries configure checker.GeoFilter: when
+	// GeoAllowCountries is non-empty, only those countries (ISO codes) are
+	// reachable from a checked config; otherwise GeoDenyCountries, if any,
+	// blocks just those. Both are on top of the always-on geoip:private block.
+	GeoAllowCountries []string
+	GeoDenyCountries  []string
+
+	// TracingEndpoint is an OTLP/gRPC collector address (e.g.
+	// "localhost:4317"). Empty disables tracing.
+	TracingEndpoint string
+
+	// NotifyConfigPath points at a notify.RegistryConfig file (YAML or
+	// JSON) listing the notification channels successful configs are
+	// dispatched to. Empty disables the notify.NotifierRegistry entirely.
+	NotifyConfigPath string
 }
 
 type TelegramConfig struct {
@@ -58,44 +198,111 @@ type TelegramConfig struct {
 	SendingInterval time.Duration
 }
 
-// Load loads configuration from environment variables with defaults value
-func Load() *Config {
-	return &Config{
+// Load loads configuration from environment variables with defaults value,
+// resolving secret-bearing fields through resolveSecret (env, "*_FILE", or
+// "vault://path#field"), then validates the result (see Config.Validate).
+func Load() (*Config, error) {
+	encryptionKey, err := resolveSecret("ENCRYPTION_KEY", "")
+	if err != nil {
+		return nil, fmt.Errorf("resolve ENCRYPTION_KEY: %w", err)
+	}
+
+	telegramBotToken, err := resolveSecret("TELEGRAM_BOT_TOKEN", "")
+	if err != nil {
+		return nil, fmt.Errorf("resolve TELEGRAM_BOT_TOKEN: %w", err)
+	}
+
+	cfg := &Config{
 		Server: ServerConfig{
-			Port:         getEnv("SERVER_PORT", "8080"),
-			Host:         getEnv("SERVER_HOST", ""),
-			ReadTimeout:  getEnvDuration("SERVER_READ_TIMEOUT", 30*time.Second),
-			WriteTimeout: getEnvDuration("SERVER_WRITE_TIMEOUT", 30*time.Second),
-			IdleTimeout:  getEnvDuration("SERVER_IDLE_TIMEOUT", 60*time.Second),
+			Port:           getEnv("SERVER_PORT", "8080"),
+			Host:           getEnv("SERVER_HOST", ""),
+			ReadTimeout:    getEnvDuration("SERVER_READ_TIMEOUT", 30*time.Second),
+			WriteTimeout:   getEnvDuration("SERVER_WRITE_TIMEOUT", 30*time.Second),
+			IdleTimeout:    getEnvDuration("SERVER_IDLE_TIMEOUT", 60*time.Second),
+			TrustedProxies: getEnvSlice("TRUSTED_PROXIES", nil),
+			GRPCPort:       getEnv("GRPC_PORT", ""),
+			MetricsPort:    getEnv("METRICS_PORT", ""),
 		},
 		Worker: WorkerConfig{
 			Count:     getEnvInt("WORKER_COUNT", 5),
 			QueueSize: getEnvInt("WORKER_QUEUE_SIZE", 100),
 		},
 		Redis: RedisConfig{
+			Mode:     RedisMode(getEnv("REDIS_MODE", string(RedisModeSingle))),
 			Addr:     getEnv("REDIS_ADDR", "localhost:6379"),
 			Password: getEnv("REDIS_PASSWORD", ""),
 			DB:       getEnvInt("REDIS_DB", 0),
+
+			SentinelAddrs:    getEnvSlice("REDIS_SENTINEL_ADDRS", nil),
+			SentinelMaster:   getEnv("REDIS_SENTINEL_MASTER", ""),
+			SentinelPassword: getEnv("REDIS_SENTINEL_PASSWORD", ""),
+
+			ClusterAddrs:   getEnvSlice("REDIS_CLUSTER_ADDRS", nil),
+			RouteByLatency: getEnvBool("REDIS_ROUTE_BY_LATENCY", false),
+			RouteRandomly:  getEnvBool("REDIS_ROUTE_RANDOMLY", false),
+
+			TLS: RedisTLSConfig{
+				Enabled:            getEnvBool("REDIS_TLS_ENABLED", false),
+				CAFile:             getEnv("REDIS_TLS_CA_FILE", ""),
+				CertFile:           getEnv("REDIS_TLS_CERT_FILE", ""),
+				KeyFile:            getEnv("REDIS_TLS_KEY_FILE", ""),
+				InsecureSkipVerify: getEnvBool("REDIS_TLS_INSECURE_SKIP_VERIFY", false),
+			},
 		},
 		Github: GithubConfig{
-			SSHKeyPath: getEnv("GITHUB_SSH_KEY_PATH", ""),
-			Owner:      getEnv("GITHUB_OWNER", ""),
-			Repo:       getEnv("GITHUB_REPO", ""),
+			SSHKeyPath:     getEnv("GITHUB_SSH_KEY_PATH", ""),
+			Owner:          getEnv("GITHUB_OWNER", ""),
+			Repo:           getEnv("GITHUB_REPO", ""),
+			StorageBackend: getEnv("GITHUB_STORAGE_BACKEND", "git"),
+			S3Endpoint:     getEnv("GITHUB_S3_ENDPOINT", ""),
+			S3Bucket:       getEnv("GITHUB_S3_BUCKET", ""),
+			S3AccessKey:    getEnv("GITHUB_S3_ACCESS_KEY", ""),
+			S3SecretKey:    getEnv("GITHUB_S3_SECRET_KEY", ""),
+			S3Region:       getEnv("GITHUB_S3_REGION", ""),
+			S3UseSSL:       getEnvBool("GITHUB_S3_USE_SSL", true),
+			FSPath:         getEnv("GITHUB_FS_PATH", ""),
+			RedisKey:       getEnv("GITHUB_REDIS_KEY", "namira:results"),
+			FlushInterval:  getEnvDuration("GITHUB_FLUSH_INTERVAL", 30*time.Second),
 		},
 		App: AppConfig{
-			LogLevel:      getEnv("LOG_LEVEL", "info"),
-			Timeout:       getEnvDuration("APP_TIMEOUT", 10*time.Second),
-			MaxConcurrent: getEnvInt("MAX_CONCURRENT", 50),
-			EncryptionKey: getEnv("ENCRYPTION_KEY", ""),
+			LogLevel:               getEnv("LOG_LEVEL", "info"),
+			Timeout:                getEnvDuration("APP_TIMEOUT", 10*time.Second),
+			MaxConcurrent:          getEnvInt("MAX_CONCURRENT", 50),
+			EncryptionKey:          encryptionKey,
+			EncryptionKeys:         getEnv("ENCRYPTION_KEYS", ""),
+			EncryptionKeyActive:    getEnv("ENCRYPTION_KEY_ACTIVE", "default"),
+			EncryptionEnvelopeMode: getEnvBool("ENCRYPTION_ENVELOPE_MODE", false),
+			ResultsCompression:     getEnv("RESULTS_COMPRESSION", "zstd"),
+			CheckEndpoints:         getEnvSlice("CHECK_ENDPOINTS", nil),
+			DebugFaultsEnabled:     getEnvBool("DEBUG_FAULTS_ENABLED", false),
+			GeoIPDBPath:            getEnv("GEOIP_DB_PATH", ""),
+			GeoIPAccountID:         getEnv("MAXMIND_ACCOUNT_ID", ""),
+			GeoIPLicenseKey:        getEnv("MAXMIND_LICENSE_KEY", ""),
+			GeoIPRefreshInterval:   getEnvDuration("GEOIP_REFRESH_INTERVAL", 7*24*time.Hour),
+			GeoAllowCountries:      getEnvSlice("GEOIP_ALLOW_COUNTRIES", nil),
+			GeoDenyCountries:       getEnvSlice("GEOIP_DENY_COUNTRIES", nil),
+			TracingEndpoint:        getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+			NotifyConfigPath:       getEnv("NOTIFY_CONFIG_PATH", ""),
 		},
 		Telegram: TelegramConfig{
-			BotToken:        getEnv("TELEGRAM_BOT_TOKEN", ""),
+			BotToken:        telegramBotToken,
 			Channel:         getEnv("TELEGRAM_CHANNEL", ""),
 			Template:        getEnv("TELEGRAM_TEMPLATE", ""),
 			ProxyURL:        getEnv("TELEGRAM_PROXY_URL", ""),
 			SendingInterval: getEnvDuration("TELEGRAM_SENDING_INTERVAL", 10*time.Second),
 		},
+		RateLimit: RateLimitConfig{
+			RPS:     getEnvFloat("RATE_LIMIT_RPS", 5),
+			Burst:   getEnvInt("RATE_LIMIT_BURST", 10),
+			LRUSize: getEnvInt("RATE_LIMIT_LRU_SIZE", 10000),
+		},
 	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return cfg, nil
 }
 
 // Helper functions to get environment variables with defaults
@@ -123,3 +330,38 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvSlice parses a comma-separated environment variable into a string slice.
+func getEnvSlice(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parts := strings.Split(value, ",")
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// SecretProvider resolves the material for an environment variable named
+// key. It returns ok=false when it has nothing to say about key, so
+// resolveSecret can fall through to the next provider.
+type SecretProvider interface {
+	Resolve(key string) (value string, ok bool, err error)
+}
+
+// envSecretProvider is the original behavior: the env var's value, verbatim.
+// It never errors and is always tried last, so it only takes effect when no
+// other provider claims key.
+type envSecretProvider struct{}
+
+func (envSecretProvider) Resolve(key string) (string, bool, error) {
+	value := os.Getenv(key)
+	return value, value != "", nil
+}
+
+// fileSecretProvider implements the Docker-secrets "*_FILE" convention:
+// ENCRYPTION_KEY_FILE=/run/secrets/encryption_key is read and trimmed
+// instead of requiring the secret itself to sit in the environment.
+type fileSecretProvider struct{}
+
+func (fileSecretProvider) Resolve(key string) (string, bool, error) {
+	path := os.Getenv(key + "_FILE")
+	if path == "" {
+		return "", false, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", true, fmt.Errorf("read %s_FILE: %w", key, err)
+	}
+	return strings.TrimSpace(string(data)), true, nil
+}
+
+// vaultSecretProvider resolves values of the form "vault://path#field" by
+// reading path's KV v2 secret from Vault's HTTP API (VAULT_ADDR, VAULT_TOKEN)
+// and pulling out field.
+type vaultSecretProvider struct {
+	addr  string
+	token string
+	// httpGet is overridable in tests; defaults to a real HTTP GET.
+	httpGet func(url, token string) ([]byte, error)
+}
+
+func newVaultSecretProvider() vaultSecretProvider {
+	return vaultSecretProvider{
+		addr:    os.Getenv("VAULT_ADDR"),
+		token:   os.Getenv("VAULT_TOKEN"),
+		httpGet: vaultHTTPGet,
+	}
+}
+
+func (v vaultSecretProvider) Resolve(key string) (string, bool, error) {
+	ref := os.Getenv(key)
+	if !strings.HasPrefix(ref, "vault://") {
+		return "", false, nil
+	}
+
+	path, field, found := strings.Cut(strings.TrimPrefix(ref, "vault://"), "#")
+	if !found || path == "" || field == "" {
+		return "", true, fmt.Errorf("invalid vault reference %q: want vault://path#field", ref)
+	}
+	if v.addr == "" || v.token == "" {
+		return "", true, fmt.Errorf("%s references %q but VAULT_ADDR/VAULT_TOKEN are not set", key, ref)
+	}
+
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimRight(v.addr, "/"), path)
+	body, err := v.httpGet(url, v.token)
+	if err != nil {
+		return "", true, fmt.Errorf("fetch %s from vault: %w", path, err)
+	}
+
+	value, err := extractVaultField(body, field)
+	if err != nil {
+		return "", true, fmt.Errorf("read field %q from %s: %w", field, path, err)
+	}
+	return value, true, nil
+}
+
+func vaultHTTPGet(url, token string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// extractVaultField pulls field out of a Vault KV v2 read response
+// ({"data":{"data":{field: value, ...}}}) without pulling in a full JSON
+// schema - this is intentionally the minimum needed to resolve one string.
+func extractVaultField(body []byte, field string) (string, error) {
+	needle := fmt.Sprintf(`"%s":"`, field)
+	idx := strings.Index(string(body), needle)
+	if idx == -1 {
+		return "", fmt.Errorf("field not present in response")
+	}
+	rest := string(body)[idx+len(needle):]
+	end := strings.IndexByte(rest, '"')
+	if end == -1 {
+		return "", fmt.Errorf("malformed response")
+	}
+	return rest[:end], nil
+}
+
+// resolveSecret resolves key through, in order, the file ("*_FILE"), vault
+// ("vault://path#field"), and plain-env providers, falling back to
+// defaultValue when none of them have anything for key.
+func resolveSecret(key, defaultValue string) (string, error) {
+	providers := []SecretProvider{fileSecretProvider{}, newVaultSecretProvider(), envSecretProvider{}}
+	for _, provider := range providers {
+		value, ok, err := provider.Resolve(key)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return value, nil
+		}
+	}
+	return defaultValue, nil
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Validate enforces invariants Load's env parsing can't catch on its own -
+// malformed values that would otherwise only surface later, deep in
+// aes.NewCipher, net.Listen, or a failed git push. Load calls this before
+// returning.
+func (c *Config) Validate() error {
+	if err := c.validateEncryptionKeys(); err != nil {
+		return err
+	}
+
+	if c.Worker.Count < 1 {
+		return fmt.Errorf("WORKER_COUNT must be >= 1, got %d", c.Worker.Count)
+	}
+
+	if _, err := strconv.ParseUint(c.Server.Port, 10, 16); err != nil {
+		return fmt.Errorf("SERVER_PORT %q is not a valid port: %w", c.Server.Port, err)
+	}
+
+	if err := c.validateGithub(); err != nil {
+		return err
+	}
+
+	if c.Telegram.Template != "" {
+		if _, err := template.New("telegram").Parse(c.Telegram.Template); err != nil {
+			return fmt.Errorf("TELEGRAM_TEMPLATE is not a valid template: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateEncryptionKeys checks that every AES key the app will actually use
+// decodes to a valid AES-128/192/256 key size, so a typo surfaces at
+// startup rather than the first aes.NewCipher call inside an encrypt.
+func (c *Config) validateEncryptionKeys() error {
+	if c.App.EncryptionKeys != "" {
+		for _, pair := range strings.Split(c.App.EncryptionKeys, ",") {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			id, hexKey, found := strings.Cut(pair, ":")
+			if !found {
+				return fmt.Errorf("invalid ENCRYPTION_KEYS entry %q: want id:hex", pair)
+			}
+			if _, err := decodeAESKey(hexKey); err != nil {
+				return fmt.Errorf("ENCRYPTION_KEYS entry %q: %w", id, err)
+			}
+		}
+		return nil
+	}
+
+	if _, err := decodeAESKey(c.App.EncryptionKey); err != nil {
+		return fmt.Errorf("ENCRYPTION_KEY: %w", err)
+	}
+	return nil
+}
+
+// decodeAESKey decodes key as hex, falling back to base64, and checks the
+// result is a valid AES key size (16/24/32 bytes for AES-128/192/256).
+func decodeAESKey(key string) ([]byte, error) {
+	decoded, err := hex.DecodeString(key)
+	if err != nil {
+		decoded, err = base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("not valid hex or base64")
+		}
+	}
+
+	switch len(decoded) {
+	case 16, 24, 32:
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("decodes to %d bytes, want 16, 24, or 32", len(decoded))
+	}
+}
+
+// validateGithub requires GITHUB_SSH_KEY_PATH to exist and be readable when
+// the "git" storage backend (the only one that uses it) is selected.
+func (c *Config) validateGithub() error {
+	if c.Github.StorageBackend != "" && c.Github.StorageBackend != "git" {
+		return nil
+	}
+
+	if c.Github.SSHKeyPath == "" {
+		return fmt.Errorf("GITHUB_SSH_KEY_PATH is required when GITHUB_STORAGE_BACKEND is %q", c.Github.StorageBackend)
+	}
+
+	f, err := os.Open(c.Github.SSHKeyPath)
+	if err != nil {
+		return fmt.Errorf("GITHUB_SSH_KEY_PATH %q is not readable: %w", c.Github.SSHKeyPath, err)
+	}
+	_ = f.Close()
+
+	return nil
+}
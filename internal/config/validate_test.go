@@ -0,0 +1,192 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// validConfig returns a Config that passes Validate() unmodified, so each
+// test case only needs to break the one field it's exercising.
+func validConfig(t *testing.T) Config {
+	t.Helper()
+	keyFile := filepath.Join(t.TempDir(), "id_ed25519")
+	if err := os.WriteFile(keyFile, []byte("not a real key"), 0o600); err != nil {
+		t.Fatalf("write temp ssh key: %v", err)
+	}
+
+	return Config{
+		Worker: WorkerConfig{Count: 4},
+		Server: ServerConfig{Port: "8080"},
+		App: AppConfig{
+			EncryptionKey: "0001020304050607080910111213141516171819202122232425262728293031",
+		},
+		Github: GithubConfig{
+			StorageBackend: "git",
+			SSHKeyPath:     keyFile,
+		},
+	}
+}
+
+func TestValidate_ValidConfig(t *testing.T) {
+	cfg := validConfig(t)
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestValidate_EncryptionKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{name: "32-byte hex", key: "0001020304050607080910111213141516171819202122232425262728293031"},
+		{name: "16-byte hex", key: "00010203040506070809101112131415"},
+		{name: "base64 32 bytes", key: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="},
+		{name: "wrong length", key: "0001020304", wantErr: true},
+		{name: "not hex or base64", key: "not a key!!", wantErr: true},
+		{name: "empty", key: "", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validConfig(t)
+			cfg.App.EncryptionKey = tc.key
+			err := cfg.Validate()
+			if tc.wantErr && err == nil {
+				t.Fatalf("Validate() with EncryptionKey %q: expected an error, got none", tc.key)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("Validate() with EncryptionKey %q: unexpected error: %v", tc.key, err)
+			}
+		})
+	}
+}
+
+func TestValidate_EncryptionKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    string
+		wantErr bool
+	}{
+		{name: "single valid entry", keys: "k1:0001020304050607080910111213141516171819202122232425262728293031"},
+		{
+			name: "multiple valid entries",
+			keys: "k1:0001020304050607080910111213141516171819202122232425262728293031," +
+				"k2:0102030405060708091011121314151617181920212223242526272829303132",
+		},
+		{name: "missing colon", keys: "k1-0001020304050607080910111213141516171819202122232425262728293031", wantErr: true},
+		{name: "bad key size", keys: "k1:0001", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validConfig(t)
+			cfg.App.EncryptionKeys = tc.keys
+			err := cfg.Validate()
+			if tc.wantErr && err == nil {
+				t.Fatalf("Validate() with EncryptionKeys %q: expected an error, got none", tc.keys)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("Validate() with EncryptionKeys %q: unexpected error: %v", tc.keys, err)
+			}
+		})
+	}
+}
+
+func TestValidate_WorkerCount(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.Worker.Count = 0
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("Validate() with Worker.Count = 0: expected an error, got none")
+	}
+}
+
+func TestValidate_ServerPort(t *testing.T) {
+	tests := []struct {
+		name    string
+		port    string
+		wantErr bool
+	}{
+		{name: "valid port", port: "8080"},
+		{name: "not a number", port: "http", wantErr: true},
+		{name: "negative", port: "-1", wantErr: true},
+		{name: "too large for uint16", port: "70000", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validConfig(t)
+			cfg.Server.Port = tc.port
+			err := cfg.Validate()
+			if tc.wantErr && err == nil {
+				t.Fatalf("Validate() with Server.Port %q: expected an error, got none", tc.port)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("Validate() with Server.Port %q: unexpected error: %v", tc.port, err)
+			}
+		})
+	}
+}
+
+func TestValidate_Github(t *testing.T) {
+	t.Run("git backend requires a readable ssh key", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Github.SSHKeyPath = ""
+		if err := cfg.Validate(); err == nil {
+			t.Fatalf("expected an error when GITHUB_SSH_KEY_PATH is empty for the git backend")
+		}
+	})
+
+	t.Run("git backend rejects a missing ssh key file", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Github.SSHKeyPath = filepath.Join(t.TempDir(), "does-not-exist")
+		if err := cfg.Validate(); err == nil {
+			t.Fatalf("expected an error when GITHUB_SSH_KEY_PATH doesn't exist")
+		}
+	})
+
+	t.Run("default backend is treated as git", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Github.StorageBackend = ""
+		if err := cfg.Validate(); err == nil {
+			t.Fatalf("expected an error: empty StorageBackend still requires a readable ssh key")
+		}
+	})
+
+	t.Run("non-git backend doesn't need an ssh key", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Github.StorageBackend = "s3"
+		cfg.Github.SSHKeyPath = ""
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("Validate() with s3 backend and no ssh key: %v", err)
+		}
+	})
+}
+
+func TestValidate_TelegramTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantErr  bool
+	}{
+		{name: "empty is allowed", template: ""},
+		{name: "valid template", template: "{{.Remark}} is {{.Status}}"},
+		{name: "unbalanced action", template: "{{.Remark", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validConfig(t)
+			cfg.Telegram.Template = tc.template
+			err := cfg.Validate()
+			if tc.wantErr && err == nil {
+				t.Fatalf("Validate() with Telegram.Template %q: expected an error, got none", tc.template)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("Validate() with Telegram.Template %q: unexpected error: %v", tc.template, err)
+			}
+		})
+	}
+}
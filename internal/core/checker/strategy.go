@@ -0,0 +1,319 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CheckOutcome is what a CheckStrategy observed about a config during a
+// single probe. Most strategies only fill Delay; the layer-7 ones also fill
+// in whichever of HTTPStatus/TLSVersion/ResolvedIP they can confirm, so
+// downstream consumers can tell *why* a node is considered working instead
+// of just that a TCP handshake succeeded.
+type CheckOutcome struct {
+	Delay      time.Duration
+	HTTPStatus int
+	TLSVersion string
+	ResolvedIP string
+}
+
+// ProxyDialFunc dials the checker's configured destination through the
+// running xray instance. Strategies use it instead of calling core.Dial
+// themselves, so they stay decoupled from the instance lifecycle.
+type ProxyDialFunc func(ctx context.Context) (net.Conn, error)
+
+// CheckStrategy is a pluggable way to decide whether a config is "working".
+// TCP reachability alone is a weak signal on censored networks, where a
+// node can accept a TCP handshake and then silently drop all layer-7
+// traffic; HTTPGetStrategy, HTTPSHandshakeStrategy, and DNSQueryStrategy
+// exist to catch that.
+type CheckStrategy interface {
+	Check(ctx context.Context, dial ProxyDialFunc) (CheckOutcome, error)
+}
+
+// TCPPingStrategy is the original behavior: write a probe payload and
+// require *some* response. It only proves the proxy forwards raw TCP.
+type TCPPingStrategy struct{}
+
+func (TCPPingStrategy) Check(ctx context.Context, dial ProxyDialFunc) (CheckOutcome, error) {
+	start := time.Now()
+	conn, err := dial(ctx)
+	if err != nil {
+		return CheckOutcome{}, fmt.Errorf("connection test failed: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("ping")); err != nil {
+		return CheckOutcome{}, fmt.Errorf("failed to write test data: %w", err)
+	}
+
+	buffer := make([]byte, 1024)
+	if _, err := conn.Read(buffer); err != nil {
+		return CheckOutcome{}, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return CheckOutcome{Delay: time.Since(start)}, nil
+}
+
+// HTTPGetStrategy issues a real HTTP GET through the proxy and requires the
+// configured status code (204 by default, matching Cloudflare's connectivity
+// check endpoint). This confirms the proxy actually forwards layer-7 HTTP,
+// not just a TCP handshake.
+type HTTPGetStrategy struct {
+	URL        string
+	WantStatus int
+}
+
+// NewHTTPGetStrategy returns an HTTPGetStrategy defaulting to Cloudflare's
+// generate_204 endpoint, a small, stable, censorship-resistant target.
+func NewHTTPGetStrategy() HTTPGetStrategy {
+	return HTTPGetStrategy{
+		URL:        "http://cp.cloudflare.com/generate_204",
+		WantStatus: http.StatusNoContent,
+	}
+}
+
+func (s HTTPGetStrategy) Check(ctx context.Context, dial ProxyDialFunc) (CheckOutcome, error) {
+	url, wantStatus := s.URL, s.WantStatus
+	if url == "" {
+		url = "http://cp.cloudflare.com/generate_204"
+	}
+	if wantStatus == 0 {
+		wantStatus = http.StatusNoContent
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dial(ctx)
+			},
+		},
+		Timeout: 0, // bounded by ctx below
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return CheckOutcome{}, fmt.Errorf("build HTTP probe request: %w", err)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return CheckOutcome{}, fmt.Errorf("HTTP probe failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	delay := time.Since(start)
+
+	if resp.StatusCode != wantStatus {
+		return CheckOutcome{HTTPStatus: resp.StatusCode}, fmt.Errorf("HTTP probe returned status %d, want %d", resp.StatusCode, wantStatus)
+	}
+
+	return CheckOutcome{Delay: delay, HTTPStatus: resp.StatusCode}, nil
+}
+
+// HTTPSHandshakeStrategy performs a TLS handshake through the proxy and
+// relies on the standard library's certificate verification, so a node that
+// MITMs or drops the handshake fails the probe even though TCP succeeded.
+type HTTPSHandshakeStrategy struct {
+	SNI string
+}
+
+func (s HTTPSHandshakeStrategy) Check(ctx context.Context, dial ProxyDialFunc) (CheckOutcome, error) {
+	sni := s.SNI
+	if sni == "" {
+		sni = "cp.cloudflare.com"
+	}
+
+	start := time.Now()
+	conn, err := dial(ctx)
+	if err != nil {
+		return CheckOutcome{}, fmt.Errorf("connection test failed: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: sni})
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return CheckOutcome{}, fmt.Errorf("TLS handshake failed: %w", err)
+	}
+
+	state := tlsConn.ConnectionState()
+	return CheckOutcome{
+		Delay:      time.Since(start),
+		TLSVersion: tlsVersionName(state.Version),
+	}, nil
+}
+
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLS1.0"
+	case tls.VersionTLS11:
+		return "TLS1.1"
+	case tls.VersionTLS12:
+		return "TLS1.2"
+	case tls.VersionTLS13:
+		return "TLS1.3"
+	default:
+		return fmt.Sprintf("0x%04x", version)
+	}
+}
+
+// DNSQueryStrategy sends a DNS-over-HTTPS query through the proxy to a
+// specific resolver and requires a real answer, confirming the node carries
+// DoH end-to-end rather than having it transparently intercepted or dropped.
+type DNSQueryStrategy struct {
+	Resolver string // DoH endpoint, e.g. "https://1.1.1.1/dns-query"
+	Query    string // domain to resolve, e.g. "cp.cloudflare.com"
+}
+
+func (s DNSQueryStrategy) Check(ctx context.Context, dial ProxyDialFunc) (CheckOutcome, error) {
+	resolver := s.Resolver
+	if resolver == "" {
+		resolver = "https://1.1.1.1/dns-query"
+	}
+	query := s.Query
+	if query == "" {
+		query = "cp.cloudflare.com"
+	}
+
+	msg, err := buildDNSQuery(query)
+	if err != nil {
+		return CheckOutcome{}, fmt.Errorf("build DNS query: %w", err)
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dial(ctx)
+			},
+		},
+	}
+
+	url := resolver + "?dns=" + base64.RawURLEncoding.EncodeToString(msg)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return CheckOutcome{}, fmt.Errorf("build DoH request: %w", err)
+	}
+	req.Header.Set("Accept", "application/dns-message")
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return CheckOutcome{}, fmt.Errorf("DoH query failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return CheckOutcome{}, fmt.Errorf("read DoH response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return CheckOutcome{}, fmt.Errorf("DoH query returned status %d", resp.StatusCode)
+	}
+
+	ip, err := firstARecord(body)
+	if err != nil {
+		return CheckOutcome{}, fmt.Errorf("parse DoH response: %w", err)
+	}
+
+	return CheckOutcome{Delay: time.Since(start), ResolvedIP: ip}, nil
+}
+
+// buildDNSQuery encodes a minimal RFC 1035 query for an A record. A fixed
+// transaction ID is fine here: every probe is a one-shot request-response
+// over its own connection, so there's no multiplexing to disambiguate.
+func buildDNSQuery(domain string) ([]byte, error) {
+	var msg []byte
+	msg = append(msg, 0x13, 0x37) // transaction ID
+	msg = append(msg, 0x01, 0x00) // flags: recursion desired
+	msg = append(msg, 0x00, 0x01) // QDCOUNT=1
+	msg = append(msg, 0x00, 0x00) // ANCOUNT=0
+	msg = append(msg, 0x00, 0x00) // NSCOUNT=0
+	msg = append(msg, 0x00, 0x00) // ARCOUNT=0
+
+	for _, label := range strings.Split(strings.TrimSuffix(domain, "."), ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return nil, fmt.Errorf("invalid DNS label %q", label)
+		}
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	msg = append(msg, 0x00)       // root label
+	msg = append(msg, 0x00, 0x01) // QTYPE=A
+	msg = append(msg, 0x00, 0x01) // QCLASS=IN
+
+	return msg, nil
+}
+
+// firstARecord walks just enough of an RFC 1035 response to find the first
+// A-record answer's address. It trusts the wire format rather than fully
+// validating it; malformed input simply fails to resolve an IP.
+func firstARecord(msg []byte) (string, error) {
+	if len(msg) < 12 {
+		return "", fmt.Errorf("response too short")
+	}
+
+	qdCount := binary.BigEndian.Uint16(msg[4:6])
+	anCount := binary.BigEndian.Uint16(msg[6:8])
+	if anCount == 0 {
+		return "", fmt.Errorf("no answers in response")
+	}
+
+	offset := 12
+	skipName := func() error {
+		for offset < len(msg) {
+			length := int(msg[offset])
+			if length == 0 {
+				offset++
+				return nil
+			}
+			if length&0xc0 == 0xc0 { // compression pointer
+				offset += 2
+				return nil
+			}
+			offset += 1 + length
+		}
+		return fmt.Errorf("truncated name")
+	}
+
+	for i := 0; i < int(qdCount); i++ {
+		if err := skipName(); err != nil {
+			return "", err
+		}
+		offset += 4 // QTYPE + QCLASS
+	}
+
+	for i := 0; i < int(anCount); i++ {
+		if err := skipName(); err != nil {
+			return "", err
+		}
+		if offset+10 > len(msg) {
+			return "", fmt.Errorf("truncated answer")
+		}
+		rrType := binary.BigEndian.Uint16(msg[offset : offset+2])
+		rdLength := int(binary.BigEndian.Uint16(msg[offset+8 : offset+10]))
+		offset += 10
+		if offset+rdLength > len(msg) {
+			return "", fmt.Errorf("truncated answer data")
+		}
+		if rrType == 1 && rdLength == 4 { // A record
+			return net.IP(msg[offset : offset+4]).String(), nil
+		}
+		offset += rdLength
+	}
+
+	return "", fmt.Errorf("no A record in response")
+}
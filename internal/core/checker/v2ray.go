@@ -5,20 +5,29 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
+	"strings"
 	"time"
 
+	"github.com/NaMiraNet/namira-core/internal/tracing"
 	v2net "github.com/xtls/xray-core/common/net"
 	core "github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/infra/conf/serial"
 
 	// Import necessary components to register them
 	_ "github.com/xtls/xray-core/app/dispatcher"
+	_ "github.com/xtls/xray-core/app/dns"
 	_ "github.com/xtls/xray-core/app/proxyman/inbound"
 	_ "github.com/xtls/xray-core/app/proxyman/outbound"
+	_ "github.com/xtls/xray-core/proxy/hysteria2"
 	_ "github.com/xtls/xray-core/proxy/socks"
 	_ "github.com/xtls/xray-core/proxy/vless"
+	_ "github.com/xtls/xray-core/proxy/vless/outbound"
+	_ "github.com/xtls/xray-core/proxy/wireguard"
 	_ "github.com/xtls/xray-core/transport/internet/grpc"
+	_ "github.com/xtls/xray-core/transport/internet/reality"
 	_ "github.com/xtls/xray-core/transport/internet/tcp"
+	_ "github.com/xtls/xray-core/transport/internet/tls"
 	_ "github.com/xtls/xray-core/transport/internet/udp"
 )
 
@@ -27,16 +36,61 @@ type Config interface {
 }
 
 type ConfigChecker interface {
-	CheckConfig(config Config) (time.Duration, error)
+	CheckConfig(config Config) (CheckOutcome, error)
+}
+
+// GeoFilter restricts which countries a checked config's traffic is allowed
+// to reach, in addition to the always-on block of private IP ranges
+// (geoip:private). Country codes are MaxMind/geoip ISO codes (e.g. "US",
+// "IR"). When Allow is non-empty, only those countries (plus the check
+// destination itself) are reachable and everything else is blackholed; Deny
+// is only consulted when Allow is empty.
+type GeoFilter struct {
+	Allow []string
+	Deny  []string
+}
+
+// DNSResolver is one entry in a DNSConfig: address is an xray-style resolver
+// spec (udp://8.8.8.8, tcp://8.8.8.8, https://1.1.1.1/dns-query,
+// tls://1.1.1.1, quic://dns.adguard.com, or a bare IP for plain UDP/53).
+// Domains, when set, restricts this resolver to matching domains instead of
+// acting as a general fallback.
+type DNSResolver struct {
+	Address      string
+	Domains      []string
+	SkipFallback bool
+}
+
+// DNSConfig is an ordered list of resolvers installed into the checked
+// instance's dns block. A nil/empty DNSConfig falls back to
+// DefaultDNSConfig.
+type DNSConfig []DNSResolver
+
+// DefaultDNSConfig resolves over DoH so that DNS-layer interference on plain
+// UDP/53 - routine on censored networks - doesn't make the reachability
+// check itself unreliable before it even reaches the proxy.
+func DefaultDNSConfig() DNSConfig {
+	return DNSConfig{
+		{Address: "https://1.1.1.1/dns-query"},
+		{Address: "https://dns.google/dns-query"},
+	}
 }
 
 type V2RayConfigChecker struct {
 	timeout     time.Duration
 	checkServer string
 	checkPort   v2net.Port
+	strategy    CheckStrategy
+	geoFilter   GeoFilter
+	dns         DNSConfig
 }
 
-func NewV2RayConfigChecker(timeout time.Duration, server string, port uint32) *V2RayConfigChecker {
+// NewV2RayConfigChecker builds a checker that dials checkServer:checkPort
+// through the config under test. strategy is optional and defaults to
+// TCPPingStrategy, preserving the checker's original behavior; pass
+// HTTPGetStrategy/HTTPSHandshakeStrategy/DNSQueryStrategy for a stronger
+// layer-7 signal on networks that let TCP through but interfere above it.
+func NewV2RayConfigChecker(timeout time.Duration, server string, port uint32, strategy ...CheckStrategy) *V2RayConfigChecker {
 	if timeout <= 0 {
 		timeout = 10 * time.Second
 	}
@@ -46,13 +100,31 @@ func NewV2RayConfigChecker(timeout time.Duration, server string, port uint32) *V
 	if port == 0 {
 		port = 80
 	}
+	var s CheckStrategy = TCPPingStrategy{}
+	if len(strategy) > 0 && strategy[0] != nil {
+		s = strategy[0]
+	}
 	return &V2RayConfigChecker{
 		timeout:     timeout,
 		checkServer: server,
 		checkPort:   v2net.Port(port),
+		strategy:    s,
 	}
 }
 
+// SetGeoFilter installs filter so subsequent CheckConfig calls route the
+// tested config's outbound traffic accordingly, on top of the always-on
+// geoip:private block.
+func (t *V2RayConfigChecker) SetGeoFilter(filter GeoFilter) {
+	t.geoFilter = filter
+}
+
+// SetDNSConfig installs dns as the resolver list for the checked instance's
+// dns block, replacing DefaultDNSConfig.
+func (t *V2RayConfigChecker) SetDNSConfig(dns DNSConfig) {
+	t.dns = dns
+}
+
 type instanceConfig struct {
 	Log       interface{}       `json:"log"`
 	Inbounds  []json.RawMessage `json:"inbounds"`
@@ -62,11 +134,47 @@ type instanceConfig struct {
 		DomainStrategy string        `json:"domainStrategy"`
 	} `json:"routing"`
 	DNS struct {
-		Servers []string `json:"servers"`
+		Servers []interface{} `json:"servers"`
 	} `json:"dns"`
 }
 
-func createInstanceConfig(outbound []byte) ([]byte, error) {
+// countryGeoIPs maps ISO country codes to xray geoip site-group references
+// usable in a routing rule's "ip" list.
+func countryGeoIPs(codes []string) []string {
+	refs := make([]string, len(codes))
+	for i, code := range codes {
+		refs[i] = "geoip:" + strings.ToLower(code)
+	}
+	return refs
+}
+
+// dnsServerEntries builds the xray dns.servers value for cfg, falling back
+// to DefaultDNSConfig when cfg is empty.
+func dnsServerEntries(cfg DNSConfig) []interface{} {
+	if len(cfg) == 0 {
+		cfg = DefaultDNSConfig()
+	}
+	entries := make([]interface{}, 0, len(cfg))
+	for _, resolver := range cfg {
+		entry := map[string]interface{}{"address": resolver.Address}
+		if len(resolver.Domains) > 0 {
+			entry["domains"] = resolver.Domains
+		}
+		if resolver.SkipFallback {
+			entry["skipFallback"] = true
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// createInstanceConfig builds the xray instance config used to probe a
+// single parsed proxy config. outbound is tagged "proxy" and always paired
+// with a "block" blackhole outbound so routing rules have somewhere to send
+// traffic they reject; filter adds country allow/deny rules on top of the
+// always-on geoip:private block; dns configures the resolvers used to reach
+// the check server and resolve any domain the config itself dials out to.
+func createInstanceConfig(outbound []byte, filter GeoFilter, dns DNSConfig) ([]byte, error) {
 	config := instanceConfig{
 		Log: map[string]interface{}{
 			"loglevel": "none",
@@ -90,37 +198,80 @@ func createInstanceConfig(outbound []byte) ([]byte, error) {
 				}
 			}`),
 		},
-		Outbounds: []json.RawMessage{outbound},
 	}
+
+	var outboundFields map[string]interface{}
+	if err := json.Unmarshal(outbound, &outboundFields); err != nil {
+		return nil, fmt.Errorf("failed to parse outbound for tagging: %w", err)
+	}
+	outboundFields["tag"] = "proxy"
+	taggedOutbound, err := json.Marshal(outboundFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to tag outbound: %w", err)
+	}
+	config.Outbounds = []json.RawMessage{
+		taggedOutbound,
+		json.RawMessage(`{"protocol": "blackhole", "tag": "block"}`),
+	}
+
+	rules := []interface{}{
+		map[string]interface{}{
+			"type":        "field",
+			"ip":          []string{"geoip:private"},
+			"outboundTag": "block",
+		},
+	}
+	if len(filter.Deny) > 0 {
+		rules = append(rules, map[string]interface{}{
+			"type":        "field",
+			"ip":          countryGeoIPs(filter.Deny),
+			"outboundTag": "block",
+		})
+	}
+	if len(filter.Allow) > 0 {
+		rules = append(rules,
+			map[string]interface{}{
+				"type":        "field",
+				"ip":          countryGeoIPs(filter.Allow),
+				"outboundTag": "proxy",
+			},
+			map[string]interface{}{
+				"type":        "field",
+				"network":     "tcp,udp",
+				"outboundTag": "block",
+			},
+		)
+	}
+	config.Routing.Rules = rules
 	config.Routing.DomainStrategy = "IPIfNonMatch"
-	config.DNS.Servers = []string{"8.8.8.8", "8.8.4.4", "1.1.1.1"}
+	config.DNS.Servers = dnsServerEntries(dns)
 
 	return json.Marshal(config)
 }
 
-func (t *V2RayConfigChecker) CheckConfig(config Config) (time.Duration, error) {
+func (t *V2RayConfigChecker) CheckConfig(config Config) (CheckOutcome, error) {
 	jsonConfig, err := config.MarshalJSON()
 	if err != nil {
-		return 0, fmt.Errorf("failed to marshal config: %w", err)
+		return CheckOutcome{}, fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	instanceConfig, err := createInstanceConfig(jsonConfig)
+	instanceConfig, err := createInstanceConfig(jsonConfig, t.geoFilter, t.dns)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create instance config: %w", err)
+		return CheckOutcome{}, fmt.Errorf("failed to create instance config: %w", err)
 	}
 
 	parsedConfig, err := serial.LoadJSONConfig(bytes.NewReader(instanceConfig))
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse config: %w", err)
+		return CheckOutcome{}, fmt.Errorf("failed to parse config: %w", err)
 	}
 
 	instance, err := core.New(parsedConfig)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create Xray instance: %w", err)
+		return CheckOutcome{}, fmt.Errorf("failed to create Xray instance: %w", err)
 	}
 
 	if err := instance.Start(); err != nil {
-		return 0, fmt.Errorf("failed to start Xray instance: %w", err)
+		return CheckOutcome{}, fmt.Errorf("failed to start Xray instance: %w", err)
 	}
 	defer instance.Close()
 
@@ -133,22 +284,11 @@ func (t *V2RayConfigChecker) CheckConfig(config Config) (time.Duration, error) {
 		Port:    t.checkPort,
 	}
 
-	start := time.Now()
-	conn, err := core.Dial(ctx, instance, dest)
-	if err != nil {
-		return 0, fmt.Errorf("connection test failed: %w", err)
-	}
-	defer conn.Close()
-
-	testData := []byte("ping")
-	if _, err := conn.Write(testData); err != nil {
-		return 0, fmt.Errorf("failed to write test data: %w", err)
-	}
-
-	buffer := make([]byte, 1024)
-	if _, err := conn.Read(buffer); err != nil {
-		return 0, fmt.Errorf("failed to read response: %w", err)
+	dial := func(ctx context.Context) (net.Conn, error) {
+		ctx, span := tracing.Tracer.Start(ctx, "xray.Dial")
+		defer span.End()
+		return core.Dial(ctx, instance, dest)
 	}
 
-	return time.Since(start), nil
+	return t.strategy.Check(ctx, dial)
 }
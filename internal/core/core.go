@@ -1,15 +1,25 @@
 package core
 
 import (
+	"context"
+	"fmt"
+	"net"
 	"runtime"
 	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"slices"
 
 	"github.com/NaMiraNet/namira-core/internal/core/checker"
 	"github.com/NaMiraNet/namira-core/internal/core/parser"
+	"github.com/NaMiraNet/namira-core/internal/faultproxy"
+	"github.com/NaMiraNet/namira-core/internal/geo"
+	"github.com/NaMiraNet/namira-core/internal/metrics"
+	"github.com/NaMiraNet/namira-core/internal/tracing"
+	workerpool "github.com/NaMiraNet/namira-core/internal/worker"
 )
 
 type CheckResultStatusType string
@@ -30,6 +40,14 @@ type Config interface {
 	MarshalJSON() ([]byte, error)
 }
 
+// GeoIPResolver resolves a proxy server (IP literal or hostname) to an ISO
+// country code. *geo.Store (backed by a local GeoLite2-Country.mmdb) and
+// *geo.HTTPResolver (the api.country.is fallback) both implement it, so Core
+// doesn't care which backend answered a lookup, and tests can inject a fake.
+type GeoIPResolver interface {
+	CountryCode(server string) string
+}
+
 type CheckResult struct {
 	Status      CheckResultStatusType
 	Protocol    string
@@ -39,13 +57,40 @@ type CheckResult struct {
 	Server      string
 	CountryCode string
 	Error       string
+
+	// HTTPStatus, TLSVersion, and ResolvedIP are populated when the configured
+	// CheckStrategy confirms layer-7 behavior, not just TCP reachability; they
+	// are zero-valued for TCPPingStrategy.
+	HTTPStatus int
+	TLSVersion string
+	ResolvedIP string
 }
 
 type Core struct {
 	checker             checker.ConfigChecker
+	checkers            map[string]checker.ConfigChecker
+	scheduler           *workerpool.EndpointScheduler
 	parser              *parser.Parser
+	geoResolver         GeoIPResolver
+	geoManager          *geo.GeoIPManager
+	metrics             *metrics.Registry
+	inflightCount       int64
+	fdLimit             int
 	remarkTemplate      RemarkTemplate
 	maxConcurrentChecks int
+	checkTimeout        time.Duration
+	dialer              faultproxy.DialContextFunc
+}
+
+// CheckEndpoint is one check-server target in a pool. Weight controls its
+// share of a job's tasks, Region is informational (surfaced in status only),
+// and MaxInflight caps how many checks may be outstanding against it at once.
+type CheckEndpoint struct {
+	Host        string
+	Port        uint32
+	Weight      int
+	Region      string
+	MaxInflight int
 }
 
 type CoreOpts struct {
@@ -54,6 +99,54 @@ type CoreOpts struct {
 	CheckPort          uint32
 	CheckMaxConcurrent int
 	RemarkTemplate     *RemarkTemplate
+
+	// CheckEndpoints, when set, replaces the single CheckServer/CheckPort
+	// with a pool of weighted endpoints that tasks are spread across. When
+	// empty, CheckServer/CheckPort is used as a single-endpoint pool.
+	CheckEndpoints []CheckEndpoint
+
+	// Dialer, when set, replaces the plain *net.Dialer used for the TCP
+	// reachability preflight against a check-server endpoint. Tests use this
+	// to inject faultproxy.Dialer and exercise failure handling without a
+	// real flaky network.
+	Dialer faultproxy.DialContextFunc
+
+	// GeoDBPath is the path to a GeoLite2-Country.mmdb used to populate
+	// CheckResult.CountryCode and, together with GeoFilter, to restrict which
+	// countries a checked config's outbound traffic may reach. Empty uses
+	// geo.DefaultDBPath.
+	GeoDBPath string
+
+	// GeoFilter, when non-empty, is applied to every check-server endpoint's
+	// checker on top of the always-on geoip:private block.
+	GeoFilter checker.GeoFilter
+
+	// GeoIPLicenseKey, when set, enables a geo.GeoIPManager that downloads
+	// GeoDBPath from MaxMind on startup if it's missing or stale and refreshes
+	// it on GeoIPRefreshInterval (default 7 days), instead of requiring an
+	// operator to place and maintain the mmdb by hand.
+	GeoIPAccountID       string
+	GeoIPLicenseKey      string
+	GeoIPRefreshInterval time.Duration
+
+	// DNS, when set, replaces checker.DefaultDNSConfig as the resolver list
+	// installed into every check-server endpoint's checker, so DNS-layer
+	// interference on plain UDP/53 doesn't make the check itself unreliable.
+	DNS checker.DNSConfig
+
+	// Metrics, when set, receives Prometheus observations for every check
+	// (namira_checks_total, namira_check_duration_seconds,
+	// namira_checks_inflight, namira_parser_errors_total,
+	// namira_fd_utilization). Nil disables metrics recording.
+	Metrics *metrics.Registry
+}
+
+// WithDialer returns a copy of opts with Dialer set, so fault-injection
+// tests can compose it onto an existing CoreOpts without touching other
+// NewCore call sites.
+func WithDialer(opts CoreOpts, dialer faultproxy.DialContextFunc) CoreOpts {
+	opts.Dialer = dialer
+	return opts
 }
 
 func calculateMaxConcurrent() int {
@@ -113,14 +206,168 @@ func NewCore(opts ...CoreOpts) *Core {
 		opts[0].CheckPort = DefaultCheckPort
 	}
 
+	endpoints := opts[0].CheckEndpoints
+	if len(endpoints) == 0 {
+		endpoints = []CheckEndpoint{{Host: opts[0].CheckServer, Port: opts[0].CheckPort, Weight: 1}}
+	}
+
+	checkers := make(map[string]checker.ConfigChecker, len(endpoints))
+	schedulerEndpoints := make([]workerpool.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		id := endpointID(ep.Host, ep.Port)
+		epChecker := checker.NewV2RayConfigChecker(opts[0].CheckTimeout, ep.Host, ep.Port)
+		epChecker.SetGeoFilter(opts[0].GeoFilter)
+		epChecker.SetDNSConfig(opts[0].DNS)
+		checkers[id] = epChecker
+		weight := ep.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		schedulerEndpoints = append(schedulerEndpoints, workerpool.Endpoint{
+			ID:          id,
+			Host:        ep.Host,
+			Port:        ep.Port,
+			Weight:      weight,
+			Region:      ep.Region,
+			MaxInflight: ep.MaxInflight,
+		})
+	}
+
+	scheduler := workerpool.NewEndpointScheduler(workerpool.EndpointSchedulerConfig{Endpoints: schedulerEndpoints})
+
+	dialer := opts[0].Dialer
+	if dialer == nil {
+		dialer = (&net.Dialer{}).DialContext
+	}
+
+	// A local mmdb answers lookups from memory with no network round trip;
+	// fall back to the rate-limited HTTP provider only when one isn't
+	// configured, so ReplaceConfigRemark still resolves countries either way.
+	var geoResolver GeoIPResolver
+	var geoManager *geo.GeoIPManager
+	if opts[0].GeoIPLicenseKey != "" {
+		geoManager = geo.NewGeoIPManager(geo.ManagerConfig{
+			DBPath:     opts[0].GeoDBPath,
+			AccountID:  opts[0].GeoIPAccountID,
+			LicenseKey: opts[0].GeoIPLicenseKey,
+			TTL:        opts[0].GeoIPRefreshInterval,
+		})
+		geoStore, err := geoManager.Start(context.Background())
+		if err != nil {
+			fmt.Printf("Warning: Could not start GeoIP manager: %v\n", err)
+			geoResolver = geo.NewHTTPResolver()
+			geoManager = nil
+		} else {
+			geoResolver = geoStore
+		}
+	} else {
+		geoStore, err := geo.NewStore(opts[0].GeoDBPath)
+		if err != nil {
+			fmt.Printf("Warning: Could not open GeoIP database: %v\n", err)
+			geoResolver = geo.NewHTTPResolver()
+		} else {
+			geoResolver = geoStore
+		}
+	}
+
 	return &Core{
-		checker:             checker.NewV2RayConfigChecker(opts[0].CheckTimeout, opts[0].CheckServer, opts[0].CheckPort),
+		checker:             checkers[schedulerEndpoints[0].ID],
+		checkers:            checkers,
+		scheduler:           scheduler,
 		parser:              parser.NewParser(),
+		geoResolver:         geoResolver,
+		geoManager:          geoManager,
+		metrics:             opts[0].Metrics,
+		fdLimit:             getSystemFDLimit(),
 		maxConcurrentChecks: opts[0].CheckMaxConcurrent,
+		checkTimeout:        opts[0].CheckTimeout,
+		dialer:              dialer,
 		remarkTemplate:      remarkTemplate,
 	}
 }
 
+// GeoIPStatus reports the active GeoIPManager's database build date and
+// last refresh time. The second return value is false when no manager is
+// configured (GeoIPLicenseKey was empty), in which case the zero Status
+// should be omitted rather than surfaced as if a refresh had occurred.
+func (c *Core) GeoIPStatus() (geo.Status, bool) {
+	if c.geoManager == nil {
+		return geo.Status{}, false
+	}
+	return c.geoManager.Status(), true
+}
+
+// RefreshGeoIP triggers an on-demand MaxMind download and hot-reload,
+// bypassing the manager's TTL. It errors if no GeoIPManager is configured.
+func (c *Core) RefreshGeoIP(ctx context.Context) error {
+	if c.geoManager == nil {
+		return fmt.Errorf("geoip manager not configured")
+	}
+	return c.geoManager.Refresh(ctx)
+}
+
+// countryCode resolves server's ISO country code via geoResolver, or "" if
+// the lookup fails.
+func (c *Core) countryCode(server string) string {
+	if c.geoResolver == nil {
+		return ""
+	}
+	return c.geoResolver.CountryCode(server)
+}
+
+// GeoCacheStats reports the active GeoIP resolver's lookup cache, for
+// surfacing in /health. It's the zero value if the resolver doesn't expose
+// cache stats.
+func (c *Core) GeoCacheStats() geo.CacheStats {
+	statter, ok := c.geoResolver.(interface{ Stats() geo.CacheStats })
+	if !ok {
+		return geo.CacheStats{}
+	}
+	return statter.Stats()
+}
+
+// trackInflightStart records one more check as started, updating both the
+// inflight gauge and (derived from the same counter) the FD utilization
+// gauge against the process's file descriptor limit.
+func (c *Core) trackInflightStart() {
+	c.metrics.IncInflight()
+	n := atomic.AddInt64(&c.inflightCount, 1)
+	c.metrics.SetFDUtilization(int(n), c.fdLimit)
+}
+
+// trackInflightEnd is the trackInflightStart counterpart, called when a
+// check finishes.
+func (c *Core) trackInflightEnd() {
+	c.metrics.DecInflight()
+	n := atomic.AddInt64(&c.inflightCount, -1)
+	c.metrics.SetFDUtilization(int(n), c.fdLimit)
+}
+
+// endpointID builds the scheduler key for a check-server endpoint.
+func endpointID(host string, port uint32) string {
+	return fmt.Sprintf("%s:%d", host, port)
+}
+
+// EndpointStatuses returns live scheduling stats for every configured
+// check-server endpoint, for surfacing in health/status responses.
+func (c *Core) EndpointStatuses() []workerpool.EndpointStatus {
+	return c.scheduler.Status()
+}
+
+// probeEndpoint performs a cheap TCP reachability check against a
+// check-server endpoint before handing a config to the (expensive) xray
+// checker, through c.dialer so injected faultproxy rules apply to it.
+func (c *Core) probeEndpoint(endpoint workerpool.Endpoint) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.checkTimeout)
+	defer cancel()
+
+	conn, err := c.dialer(ctx, "tcp", endpointID(endpoint.Host, endpoint.Port))
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
 func (c *Core) CheckConfigs(configs []string) <-chan CheckResult {
 	results := make(chan CheckResult)
 	var wg sync.WaitGroup
@@ -138,25 +385,58 @@ func (c *Core) CheckConfigs(configs []string) <-chan CheckResult {
 					Raw:    cfg,
 				}
 
+				protocol, _, _ := strings.Cut(cfg, "://")
+				c.trackInflightStart()
+				defer c.trackInflightEnd()
+				checkStart := time.Now()
+
+				_, parseSpan := tracing.Tracer.Start(context.Background(), "parser.Parse")
 				parsed, err := c.parser.Parse(cfg)
+				parseSpan.End()
 
 				if err != nil {
 					result.Status = CheckResultStatusError
 					result.Error = err.Error()
+					c.metrics.ObserveParserError(protocol, "parse_error")
+					results <- result
+					return
+				}
+
+				endpoint := c.scheduler.PickForJob(index, len(configs))
+				if endpoint == nil {
+					result.Status = CheckResultStatusError
+					result.Error = "no healthy check endpoint available"
 					results <- result
 					return
 				}
 
-				delay, err := c.checker.CheckConfig(parsed)
+				if err := c.probeEndpoint(*endpoint); err != nil {
+					c.scheduler.Release(endpoint.ID, false)
+					result.Status = CheckResultStatusError
+					result.Error = fmt.Sprintf("check endpoint unreachable: %v", err)
+					results <- result
+					return
+				}
+
+				_, checkSpan := tracing.Tracer.Start(context.Background(), "checker.CheckConfig")
+				outcome, err := c.checkers[endpoint.ID].CheckConfig(parsed)
+				checkSpan.End()
+
+				c.scheduler.Release(endpoint.ID, err == nil)
 				c.FillCheckResult(&result)
 
 				if err != nil {
 					result.Status = CheckResultStatusError
 					result.Error = err.Error()
 				} else {
-					result.RealDelay = delay
+					result.RealDelay = outcome.Delay
+					result.HTTPStatus = outcome.HTTPStatus
+					result.TLSVersion = outcome.TLSVersion
+					result.ResolvedIP = outcome.ResolvedIP
 				}
 
+				c.metrics.ObserveCheck(protocol, string(result.Status), time.Since(checkStart))
+
 				results <- result
 			}(i, config)
 		}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"errors"
+	"net"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NaMiraNet/namira-core/internal/faultproxy"
+	workerpool "github.com/NaMiraNet/namira-core/internal/worker"
+)
+
+// newTestListener starts a local TCP listener that accepts and immediately
+// closes every connection, standing in for a check-server endpoint.
+func newTestListener(t *testing.T) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	t.Cleanup(func() { ln.Close() })
+	return ln
+}
+
+// TestCore_ProbeEndpoint_FaultProfiles runs probeEndpoint — the TCP
+// reachability preflight CheckConfigs gates on before handing a config to
+// the xray checker, through c.dialer so injected faultproxy rules apply to
+// it — under each fault profile, and asserts the resulting error (or lack
+// of one) and timing match what that profile should produce.
+func TestCore_ProbeEndpoint_FaultProfiles(t *testing.T) {
+	ln := newTestListener(t)
+	host, portStr, err := net.SplitHostPort(ln.Addr().String())
+	if err != nil {
+		t.Fatalf("split listener addr: %v", err)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 32)
+	if err != nil {
+		t.Fatalf("parse port: %v", err)
+	}
+	endpoint := workerpool.Endpoint{Host: host, Port: uint32(port)}
+
+	tests := []struct {
+		name      string
+		profile   faultproxy.Profile
+		timeout   time.Duration
+		wantErr   error
+		wantSlow  time.Duration
+		expectErr bool
+	}{
+		{
+			name:    "no fault succeeds",
+			profile: faultproxy.Profile{},
+			timeout: time.Second,
+		},
+		{
+			name:      "rst fails immediately",
+			profile:   faultproxy.Profile{RST: true},
+			timeout:   time.Second,
+			wantErr:   faultproxy.ErrReset,
+			expectErr: true,
+		},
+		{
+			name:      "drop fails immediately",
+			profile:   faultproxy.Profile{DropProbability: 1},
+			timeout:   time.Second,
+			wantErr:   faultproxy.ErrDropped,
+			expectErr: true,
+		},
+		{
+			name:      "blackhole times out",
+			profile:   faultproxy.Profile{Blackhole: true},
+			timeout:   50 * time.Millisecond,
+			expectErr: true,
+		},
+		{
+			name:     "added latency delays success",
+			profile:  faultproxy.Profile{AddedLatency: 30 * time.Millisecond},
+			timeout:  time.Second,
+			wantSlow: 30 * time.Millisecond,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := faultproxy.NewRegistry()
+			if err := registry.Install(regexp.QuoteMeta(host), tc.profile); err != nil {
+				t.Fatalf("install rule: %v", err)
+			}
+			dialer := faultproxy.NewDialer(registry, nil)
+
+			c := &Core{dialer: dialer.DialContext, checkTimeout: tc.timeout}
+
+			start := time.Now()
+			err := c.probeEndpoint(endpoint)
+			elapsed := time.Since(start)
+
+			if tc.expectErr && err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+			if !tc.expectErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if tc.wantSlow > 0 && elapsed < tc.wantSlow {
+				t.Fatalf("expected probe to take at least %v, took %v", tc.wantSlow, elapsed)
+			}
+		})
+	}
+}
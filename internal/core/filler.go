@@ -3,31 +3,23 @@ package core
 import (
 	"encoding/base64"
 	"encoding/json"
-	"fmt"
 	"net"
 	"net/url"
 	"strings"
 
 	"github.com/enescakir/emoji"
-	"github.com/oschwald/geoip2-golang"
 )
 
 // Protocol emojis
 var protocolEmojis = map[string]emoji.Emoji{
-	"vmess":  emoji.HighVoltage,
-	"vless":  emoji.Rocket,
-	"trojan": emoji.Shield,
-	"ss":     emoji.Locked,
-}
-
-var geoipDB *geoip2.Reader
-
-func init() {
-	var err error
-	geoipDB, err = geoip2.Open("GeoLite2-Country.mmdb")
-	if err != nil {
-		fmt.Printf("Warning: Could not open GeoLite2 database: %v\n", err)
-	}
+	"vmess":     emoji.HighVoltage,
+	"vless":     emoji.Rocket,
+	"trojan":    emoji.Shield,
+	"ss":        emoji.Locked,
+	"hysteria2": emoji.SatelliteAntenna,
+	"tuic":      emoji.Key,
+	"wg":        emoji.Package,
+	"wireguard": emoji.Package,
 }
 
 type CountryResponse struct {
@@ -68,7 +60,7 @@ func (c *Core) FillCheckResult(result *CheckResult, template ...RemarkTemplate)
 	switch result.Protocol {
 	case "vmess":
 		c.fillVMessResult(result, tmpl)
-	case "vless", "trojan", "ss":
+	case "vless", "trojan", "ss", "hysteria2", "tuic", "wg", "wireguard":
 		c.fillURLResult(result, tmpl, result.Protocol)
 	}
 }
@@ -93,7 +85,7 @@ func (c *Core) fillVMessResult(result *CheckResult, tmpl RemarkTemplate) {
 	// Extract server info
 	server, _ := vmessConfig["add"].(string)
 	result.Server = server
-	result.CountryCode = getCountryFromServer(server)
+	result.CountryCode = c.countryCode(server)
 	result.Remark = c.generateRemark(server, "vmess", tmpl)
 	vmessConfig["ps"] = result.Remark
 
@@ -105,7 +97,7 @@ func (c *Core) fillVMessResult(result *CheckResult, tmpl RemarkTemplate) {
 func (c *Core) fillURLResult(result *CheckResult, tmpl RemarkTemplate, protocol string) {
 	result.Raw = strings.Split(result.Raw, "#")[0]
 	server := extractServerFromURL(result.Raw)
-	result.CountryCode = getCountryFromServer(server)
+	result.CountryCode = c.countryCode(server)
 	result.Remark = c.generateRemark(server, protocol, tmpl)
 	result.Server = server
 	result.Raw += "#" + url.PathEscape(result.Remark)
@@ -127,7 +119,7 @@ func (c *Core) generateRemark(server, protocol string, tmpl RemarkTemplate) stri
 	}
 
 	if tmpl.ShowCountry && server != "" {
-		if countryCode := getCountryFromServer(server); countryCode != "" {
+		if countryCode := c.countryCode(server); countryCode != "" {
 			if countryFlag, err := emoji.CountryFlag(countryCode); err == nil {
 				parts = append(parts, countryFlag.String())
 			} else {
@@ -139,36 +131,6 @@ func (c *Core) generateRemark(server, protocol string, tmpl RemarkTemplate) stri
 	return strings.Join(parts, tmpl.Separator)
 }
 
-func getCountryFromServer(server string) string {
-	if server == "" || geoipDB == nil {
-		return ""
-	}
-
-	ip := server
-	if !net.ParseIP(server).IsUnspecified() {
-		if ips, err := net.LookupIP(server); err == nil && len(ips) > 0 {
-			ip = ips[0].String()
-		}
-	}
-
-	// Try to parse IP
-	parsedIP := net.ParseIP(ip)
-	if parsedIP == nil {
-		return ""
-	}
-
-	// Lookup country
-	record, err := geoipDB.Country(parsedIP)
-	if err != nil {
-		return ""
-	}
-
-	if code := record.Country.IsoCode; code != "" {
-		return code
-	}
-	return record.RegisteredCountry.IsoCode
-}
-
 func extractServerFromURL(config string) string {
 	// Remove protocol
 	parts := strings.SplitN(config, "://", 2)
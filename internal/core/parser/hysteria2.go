@@ -0,0 +1,105 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+type hysteria2Config struct {
+	Raw           string `json:"-"`
+	Server        string `json:"server"`
+	Port          int    `json:"port"`
+	Password      string `json:"password"`
+	SNI           string `json:"sni,omitempty"`
+	ObfsType      string `json:"obfsType,omitempty"`
+	ObfsPassword  string `json:"obfsPassword,omitempty"`
+	AllowInsecure bool   `json:"allowInsecure,omitempty"`
+	Remark        string `json:"remark,omitempty"`
+}
+
+type hysteria2JSONObfs struct {
+	Type     string `json:"type"`
+	Password string `json:"password,omitempty"`
+}
+
+type hysteria2JSONSettings struct {
+	Address       string             `json:"address"`
+	Port          int                `json:"port"`
+	Password      string             `json:"password"`
+	ServerName    string             `json:"serverName,omitempty"`
+	Obfs          *hysteria2JSONObfs `json:"obfs,omitempty"`
+	AllowInsecure bool               `json:"allowInsecure,omitempty"`
+}
+
+// Endpoint implements parser.Endpoint.
+func (c *hysteria2Config) Endpoint() (string, int) {
+	return c.Server, c.Port
+}
+
+func (c *hysteria2Config) MarshalJSON() ([]byte, error) {
+	settings := hysteria2JSONSettings{
+		Address:       c.Server,
+		Port:          c.Port,
+		Password:      c.Password,
+		ServerName:    c.SNI,
+		AllowInsecure: c.AllowInsecure,
+	}
+	if c.ObfsType != "" {
+		settings.Obfs = &hysteria2JSONObfs{Type: c.ObfsType, Password: c.ObfsPassword}
+	}
+
+	outboundConfig := map[string]interface{}{
+		"protocol": "hysteria2",
+		"settings": settings,
+	}
+
+	return json.Marshal(outboundConfig)
+}
+
+// parseHysteria2 parses a hysteria2://password@host:port?sni=...&obfs=salamander&obfs-password=...#remark
+// link. A missing/invalid port falls back to DefaultPort("hysteria2")
+// instead of failing outright.
+func parseHysteria2(link string) (Config, error) {
+	parsedURL, err := url.Parse(link)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Hysteria2 link format: %v", err)
+	}
+
+	config := &hysteria2Config{
+		Raw:      link,
+		Password: parsedURL.User.Username(),
+	}
+
+	host := parsedURL.Hostname()
+	if host == "" {
+		return nil, fmt.Errorf("server address is required")
+	}
+	config.Server = host
+
+	if portStr := parsedURL.Port(); portStr != "" {
+		if config.Port, err = strconv.Atoi(portStr); err != nil {
+			return nil, fmt.Errorf("invalid port: %v", err)
+		}
+	} else {
+		config.Port = DefaultPort("hysteria2")
+	}
+
+	params := parsedURL.Query()
+	config.SNI = params.Get("sni")
+	config.ObfsType = params.Get("obfs")
+	config.ObfsPassword = params.Get("obfs-password")
+	if params.Get("insecure") == "1" || params.Get("insecure") == "true" {
+		config.AllowInsecure = true
+	}
+	if parsedURL.Fragment != "" {
+		config.Remark, _ = url.QueryUnescape(parsedURL.Fragment)
+	}
+
+	if config.Password == "" {
+		return nil, fmt.Errorf("password is required")
+	}
+
+	return config, nil
+}
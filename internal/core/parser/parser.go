@@ -9,12 +9,36 @@ type Config interface {
 	MarshalJSON() ([]byte, error)
 }
 
+// Endpoint is implemented by every Config and exposes the remote host:port
+// it dials, so callers (e.g. internal/service) can report connectivity
+// info without re-parsing the link themselves.
+type Endpoint interface {
+	Endpoint() (host string, port int)
+}
+
 var (
 	ErrInvalidConfig   = fmt.Errorf("invalid config")
 	ErrUnsupportedType = fmt.Errorf("unsupported config type")
 	ErrInvalidFormat   = fmt.Errorf("invalid config format")
 )
 
+// defaultPorts gives a protocol fallback port so a link with a missing or
+// malformed port gets a sensible default instead of a hard parse failure.
+var defaultPorts = map[string]int{
+	"vmess":     443,
+	"vless":     443,
+	"trojan":    443,
+	"ss":        8388,
+	"hysteria2": 443,
+	"tuic":      443,
+	"wireguard": 51820,
+}
+
+// DefaultPort returns the fallback port for protocol, or 0 if none is known.
+func DefaultPort(protocol string) int {
+	return defaultPorts[protocol]
+}
+
 type basicOutboundConfig struct {
 	Protocol string `json:"protocol"`
 	Settings any    `json:"settings"`
@@ -29,10 +53,13 @@ type Parser struct {
 func NewParser() *Parser {
 	return &Parser{
 		parsers: map[string]ConfigParser{
-			"ss":     parseSS,
-			"vless":  parseVless,
-			"vmess":  parseVMess,
-			"trojan": parseTrojan,
+			"ss":        parseSS,
+			"vless":     parseVless,
+			"vmess":     parseVMess,
+			"trojan":    parseTrojan,
+			"hysteria2": parseHysteria2,
+			"tuic":      parseTUIC,
+			"wireguard": parseWireGuard,
 		},
 	}
 }
@@ -42,6 +69,12 @@ func (p *Parser) Parse(config string) (Config, error) {
 		return nil, ErrInvalidFormat
 	}
 
+	// WireGuard is also commonly shared as a raw [Interface]/[Peer] INI
+	// block (no scheme), so it needs to be detected before the "://" split.
+	if trimmed := strings.TrimSpace(config); strings.HasPrefix(trimmed, "[Interface]") {
+		return parseWireGuard(trimmed)
+	}
+
 	parts := strings.SplitN(config, "://", 2)
 	if len(parts) != 2 {
 		return nil, ErrInvalidFormat
@@ -10,27 +10,43 @@ import (
 	"strings"
 )
 
+// SSPlugin is a parsed SIP003 plugin directive from a Shadowsocks link's
+// `plugin` query parameter, e.g. "obfs-local;obfs=http;obfs-host=example.com"
+// becomes Name="obfs-local", Opts={"obfs":"http","obfs-host":"example.com"}.
+type SSPlugin struct {
+	Name string
+	Opts map[string]string
+}
+
 type ssConfig struct {
 	Raw      string `json:"-"`
 	Server   string `json:"server"`
 	Port     int    `json:"port"`
 	Method   string `json:"method"`
 	Password string `json:"password"`
+	Plugin   *SSPlugin
 	Remark   string `json:"remark"`
 }
 
 type ssJSONServer struct {
-	Address  string `json:"address"`
-	Method   string `json:"method"`
-	OTA      bool   `json:"ota"`
-	Password string `json:"password"`
-	Port     int    `json:"port"`
+	Address    string `json:"address"`
+	Method     string `json:"method"`
+	OTA        bool   `json:"ota"`
+	Password   string `json:"password"`
+	Port       int    `json:"port"`
+	Plugin     string `json:"plugin,omitempty"`
+	PluginOpts string `json:"pluginOpts,omitempty"`
 }
 
 type ssJSONSettings struct {
 	Servers []ssJSONServer `json:"servers"`
 }
 
+// Endpoint implements parser.Endpoint.
+func (c *ssConfig) Endpoint() (string, int) {
+	return c.Server, c.Port
+}
+
 func (c *ssConfig) MarshalJSON() ([]byte, error) {
 	server := ssJSONServer{
 		Address:  c.Server,
@@ -38,64 +54,158 @@ func (c *ssConfig) MarshalJSON() ([]byte, error) {
 		Password: c.Password,
 		Port:     c.Port,
 	}
+	if c.Plugin != nil {
+		server.Plugin = c.Plugin.Name
+		server.PluginOpts = encodePluginOpts(c.Plugin.Opts)
+	}
 	return json.Marshal(basicOutboundConfig{
 		Protocol: "shadowsocks",
 		Settings: ssJSONSettings{Servers: []ssJSONServer{server}},
 	})
 }
 
+func encodePluginOpts(opts map[string]string) string {
+	if len(opts) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(opts))
+	for k, v := range opts {
+		if v == "" {
+			parts = append(parts, k)
+			continue
+		}
+		parts = append(parts, k+"="+v)
+	}
+	return strings.Join(parts, ";")
+}
+
+// decodeB64 tries the four base64 alphabets SIP002 implementations are seen
+// to emit: standard/URL-safe, each with and without padding.
+func decodeB64(s string) ([]byte, error) {
+	decoders := []*base64.Encoding{
+		base64.StdEncoding,
+		base64.URLEncoding,
+		base64.RawStdEncoding,
+		base64.RawURLEncoding,
+	}
+	var lastErr error
+	for _, enc := range decoders {
+		if data, err := enc.DecodeString(s); err == nil {
+			return data, nil
+		} else {
+			lastErr = err
+		}
+	}
+	return nil, lastErr
+}
+
+// parsePlugin splits a SIP003 plugin string ("name;k=v;k2=v2") into an SSPlugin.
+func parsePlugin(raw string) *SSPlugin {
+	if raw == "" {
+		return nil
+	}
+	segments := strings.Split(raw, ";")
+	plugin := &SSPlugin{Name: segments[0], Opts: make(map[string]string)}
+	for _, seg := range segments[1:] {
+		if seg == "" {
+			continue
+		}
+		if k, v, found := strings.Cut(seg, "="); found {
+			plugin.Opts[k] = v
+		} else {
+			plugin.Opts[seg] = ""
+		}
+	}
+	return plugin
+}
+
+// parseMethodAndPassword splits a decoded "method:password" credential blob.
+func parseMethodAndPassword(decoded string) (method, password string, err error) {
+	method, password, ok := strings.Cut(decoded, ":")
+	if !ok {
+		return "", "", fmt.Errorf("invalid ShadowSocks link format: invalid auth format")
+	}
+	return method, password, nil
+}
+
 func parseSS(link string) (Config, error) {
 	if !strings.HasPrefix(link, "ss://") {
 		return nil, fmt.Errorf("invalid ShadowSocks link format")
 	}
 
-	// Remove ss:// prefix
 	link = strings.TrimPrefix(link, "ss://")
 
 	config := &ssConfig{Raw: link}
 
-	// Handle remark (fragment)
-	parts := strings.Split(link, "#")
-	if len(parts) == 2 {
-		config.Remark, _ = url.QueryUnescape(parts[1])
-		link = parts[0]
+	// Fragment (remark) is always last.
+	if body, remark, found := strings.Cut(link, "#"); found {
+		config.Remark, _ = url.QueryUnescape(remark)
+		link = body
 	}
 
-	// Split by @ to separate auth and server info
-	atIndex := strings.LastIndex(link, "@")
-	if atIndex == -1 {
-		return nil, fmt.Errorf("invalid ShadowSocks link format")
+	// Query string (plugin=...) comes before the fragment.
+	var query string
+	if body, q, found := strings.Cut(link, "?"); found {
+		link = body
+		query = q
 	}
 
-	authPart := link[:atIndex]
-	serverPart := link[atIndex+1:]
-
-	// Decode auth part (method:password)
-	authData, err := base64.StdEncoding.DecodeString(authPart)
-	if err != nil {
-		// Try URL-safe base64
-		authData, err = base64.URLEncoding.DecodeString(authPart)
+	if query != "" {
+		values, err := url.ParseQuery(query)
 		if err != nil {
-			// Try raw standard encoding
-			authData, err = base64.RawStdEncoding.DecodeString(authPart)
+			return nil, fmt.Errorf("invalid ShadowSocks link format: invalid query: %w", err)
+		}
+		config.Plugin = parsePlugin(values.Get("plugin"))
+	}
+
+	var authPart, serverPart string
+	if atIndex := strings.LastIndex(link, "@"); atIndex != -1 {
+		// SIP002: ss://userinfo@host:port, where userinfo is either
+		// base64url(method:password) or plain method:password.
+		authPart, serverPart = link[:atIndex], link[atIndex+1:]
+
+		var method, password string
+		if decoded, err := decodeB64(authPart); err == nil {
+			method, password, err = parseMethodAndPassword(string(decoded))
 			if err != nil {
-				// Try raw URL encoding
-				authData, err = base64.RawURLEncoding.DecodeString(authPart)
-				if err != nil {
-					return nil, fmt.Errorf("invalid ShadowSocks link format: failed to decode auth")
-				}
+				return nil, err
+			}
+		} else {
+			unescaped, err := url.QueryUnescape(authPart)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ShadowSocks link format: invalid auth encoding")
+			}
+			method, password, err = parseMethodAndPassword(unescaped)
+			if err != nil {
+				return nil, err
 			}
 		}
-	}
+		config.Method, config.Password = method, password
+	} else {
+		// Legacy form: ss://base64(method:password@host:port), whole blob encoded.
+		decoded, err := decodeB64(link)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ShadowSocks link format: failed to decode")
+		}
 
-	auth := strings.Split(string(authData), ":")
-	if len(auth) != 2 {
-		return nil, fmt.Errorf("invalid ShadowSocks link format: invalid auth format")
+		decodedAtIndex := strings.LastIndex(string(decoded), "@")
+		if decodedAtIndex == -1 {
+			return nil, fmt.Errorf("invalid ShadowSocks link format")
+		}
+		authPart, serverPart = string(decoded)[:decodedAtIndex], string(decoded)[decodedAtIndex+1:]
+
+		method, password, err := parseMethodAndPassword(authPart)
+		if err != nil {
+			return nil, err
+		}
+		config.Method, config.Password = method, password
 	}
-	config.Method = auth[0]
-	config.Password = auth[1]
 
-	// Parse server and port
+	// Canonical SIP002 links end the server part with a mandatory "/" before
+	// the query string (ss://.../host:port/?plugin=..."), which SplitHostPort
+	// would otherwise bake into the port.
+	serverPart = strings.TrimSuffix(serverPart, "/")
+
 	host, portStr, err := net.SplitHostPort(serverPart)
 	if err != nil {
 		return nil, fmt.Errorf("invalid ShadowSocks link format: invalid server format")
@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// sip002Golden pairs a Shadowsocks link against the exact outbound JSON
+// parseSS + MarshalJSON should produce for it, covering the SIP002 examples
+// from the spec plus the legacy whole-blob form and IPv6 hosts. Links whose
+// plugin carries more than one option are checked separately (below),
+// because encodePluginOpts joins a Go map and isn't ordering-stable.
+var sip002Golden = []struct {
+	name string
+	link string
+	want string
+}{
+	{
+		name: "sip002 userinfo, no plugin",
+		// aes-256-gcm:test, base64url per the spec's own example.
+		link: "ss://YWVzLTI1Ni1nY206dGVzdA@192.168.100.1:8888#Example1",
+		want: `{"protocol":"shadowsocks","settings":{"servers":[{"address":"192.168.100.1","method":"aes-256-gcm","ota":false,"password":"test","port":8888}]}}`,
+	},
+	{
+		name: "sip002 trailing slash before query",
+		link: "ss://YWVzLTI1Ni1nY206dGVzdA@192.168.100.1:8888/?plugin=simple-obfs#Example2",
+		want: `{"protocol":"shadowsocks","settings":{"servers":[{"address":"192.168.100.1","method":"aes-256-gcm","ota":false,"password":"test","port":8888,"plugin":"simple-obfs"}]}}`,
+	},
+	{
+		name: "plain percent-encoded userinfo",
+		link: "ss://aes-256-gcm:test@192.168.100.1:8888#Example3",
+		want: `{"protocol":"shadowsocks","settings":{"servers":[{"address":"192.168.100.1","method":"aes-256-gcm","ota":false,"password":"test","port":8888}]}}`,
+	},
+	{
+		name: "legacy whole-blob base64",
+		// base64("aes-256-gcm:test@192.168.100.1:8888"), no userinfo separator.
+		link: "ss://YWVzLTI1Ni1nY206dGVzdEAxOTIuMTY4LjEwMC4xOjg4ODg=#Legacy",
+		want: `{"protocol":"shadowsocks","settings":{"servers":[{"address":"192.168.100.1","method":"aes-256-gcm","ota":false,"password":"test","port":8888}]}}`,
+	},
+	{
+		name: "ipv6 host in brackets",
+		// base64("chacha20-ietf-poly1305:hunter2@[2001:db8::1]:8989").
+		link: "ss://Y2hhY2hhMjAtaWV0Zi1wb2x5MTMwNTpodW50ZXIyQFsyMDAxOmRiODo6MV06ODk4OQ==#IPv6",
+		want: `{"protocol":"shadowsocks","settings":{"servers":[{"address":"2001:db8::1","method":"chacha20-ietf-poly1305","ota":false,"password":"hunter2","port":8989}]}}`,
+	},
+}
+
+func TestParseSS_Golden(t *testing.T) {
+	for _, tc := range sip002Golden {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := parseSS(tc.link)
+			if err != nil {
+				t.Fatalf("parseSS(%q) returned error: %v", tc.link, err)
+			}
+			got, err := cfg.MarshalJSON()
+			if err != nil {
+				t.Fatalf("MarshalJSON: %v", err)
+			}
+			if string(got) != tc.want {
+				t.Fatalf("MarshalJSON mismatch\n got: %s\nwant: %s", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestParseSS_PluginMultiOption covers a plugin string with multiple
+// key=value options (e.g. "obfs-local;obfs=http;obfs-host=example.com"),
+// asserting against the parsed SSPlugin directly rather than MarshalJSON
+// output, since encodePluginOpts joins options from a Go map and its output
+// order isn't guaranteed.
+func TestParseSS_PluginMultiOption(t *testing.T) {
+	// plugin=obfs-local;obfs=http;obfs-host=example.com, percent-encoded for
+	// the query string as SIP002 requires.
+	link := "ss://YWVzLTI1Ni1nY206dGVzdA@192.168.100.1:8888/?plugin=obfs-local%3Bobfs%3Dhttp%3Bobfs-host%3Dexample.com#Example4"
+
+	cfg, err := parseSS(link)
+	if err != nil {
+		t.Fatalf("parseSS(%q) returned error: %v", link, err)
+	}
+
+	ss, ok := cfg.(*ssConfig)
+	if !ok {
+		t.Fatalf("parseSS returned %T, want *ssConfig", cfg)
+	}
+	if ss.Plugin == nil {
+		t.Fatalf("expected a parsed plugin, got nil")
+	}
+	if ss.Plugin.Name != "obfs-local" {
+		t.Errorf("plugin name = %q, want %q", ss.Plugin.Name, "obfs-local")
+	}
+	want := map[string]string{"obfs": "http", "obfs-host": "example.com"}
+	if len(ss.Plugin.Opts) != len(want) {
+		t.Fatalf("plugin opts = %v, want %v", ss.Plugin.Opts, want)
+	}
+	for k, v := range want {
+		if ss.Plugin.Opts[k] != v {
+			t.Errorf("plugin opts[%q] = %q, want %q", k, ss.Plugin.Opts[k], v)
+		}
+	}
+
+	// MarshalJSON should still round-trip a valid outbound config, even
+	// though the exact key order of "plugin;k=v;k2=v2" isn't guaranteed.
+	raw, err := cfg.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+	var decoded struct {
+		Settings struct {
+			Servers []struct {
+				Plugin     string `json:"plugin"`
+				PluginOpts string `json:"pluginOpts"`
+			} `json:"servers"`
+		} `json:"settings"`
+	}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal outbound config: %v", err)
+	}
+	if len(decoded.Settings.Servers) != 1 {
+		t.Fatalf("expected 1 server, got %d", len(decoded.Settings.Servers))
+	}
+	if decoded.Settings.Servers[0].Plugin != "obfs-local" {
+		t.Errorf("marshaled plugin = %q, want %q", decoded.Settings.Servers[0].Plugin, "obfs-local")
+	}
+}
+
+func TestParseSS_InvalidFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+	}{
+		{name: "missing prefix", link: "vmess://abc"},
+		{name: "garbage userinfo", link: "ss://not-valid-base64-or-creds@host:8888"},
+		{name: "missing port", link: "ss://YWVzLTI1Ni1nY206dGVzdA@192.168.100.1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := parseSS(tc.link); err == nil {
+				t.Fatalf("parseSS(%q) expected an error, got none", tc.link)
+			}
+		})
+	}
+}
@@ -50,6 +50,11 @@ type trojanJSONSettings struct {
 	Servers []trojanJSONServer `json:"servers"`
 }
 
+// Endpoint implements parser.Endpoint.
+func (c *trojanConfig) Endpoint() (string, int) {
+	return c.Server, c.Port
+}
+
 func (c *trojanConfig) MarshalJSON() ([]byte, error) {
 	server := trojanJSONServer{
 		Address:  c.Server,
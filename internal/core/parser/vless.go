@@ -27,6 +27,26 @@ type vlessConfig struct {
 	Authority   string `json:"authority,omitempty"`
 	ServiceName string `json:"serviceName,omitempty"`
 	Remark      string `json:"remark,omitempty"`
+
+	// Fingerprint is the uTLS client-hello fingerprint ("fp" query param),
+	// forwarded in tlsSettings (Security == "tls") or realitySettings
+	// (Security == "reality").
+	Fingerprint string `json:"-"`
+
+	// REALITY parameters, only set when Security == "reality".
+	RealityPublicKey string `json:"-"`
+	RealityShortID   string `json:"-"`
+	RealitySpiderX   string `json:"-"`
+}
+
+// validFlows are the XTLS flow control values namira-core will forward to
+// xray-core. Anything else is rejected rather than silently passed through,
+// since an unrecognized flow makes xray-core reject the outbound at runtime
+// with an error that is much harder to trace back to the link.
+var validFlows = map[string]bool{
+	"":                        true,
+	"xtls-rprx-vision":        true,
+	"xtls-rprx-vision-udp443": true,
 }
 
 type vlessJSONUser struct {
@@ -42,21 +62,27 @@ type vlessJSONVnext struct {
 }
 
 type vlessJSONStreamSettings struct {
-	Network      string                 `json:"network"`
-	Security     string                 `json:"security,omitempty"`
-	WSSettings   map[string]interface{} `json:"wsSettings,omitempty"`
-	TCPSettings  map[string]interface{} `json:"tcpSettings,omitempty"`
-	KCPSettings  map[string]interface{} `json:"kcpSettings,omitempty"`
-	HTTPSettings map[string]interface{} `json:"httpSettings,omitempty"`
-	QUICSettings map[string]interface{} `json:"quicSettings,omitempty"`
-	GRPCSettings map[string]interface{} `json:"grpcSettings,omitempty"`
-	TLSSettings  map[string]interface{} `json:"tlsSettings,omitempty"`
+	Network         string                 `json:"network"`
+	Security        string                 `json:"security,omitempty"`
+	WSSettings      map[string]interface{} `json:"wsSettings,omitempty"`
+	TCPSettings     map[string]interface{} `json:"tcpSettings,omitempty"`
+	KCPSettings     map[string]interface{} `json:"kcpSettings,omitempty"`
+	HTTPSettings    map[string]interface{} `json:"httpSettings,omitempty"`
+	QUICSettings    map[string]interface{} `json:"quicSettings,omitempty"`
+	GRPCSettings    map[string]interface{} `json:"grpcSettings,omitempty"`
+	TLSSettings     map[string]interface{} `json:"tlsSettings,omitempty"`
+	RealitySettings map[string]interface{} `json:"realitySettings,omitempty"`
 }
 
 type vlessJSONSettings struct {
 	Vnext []vlessJSONVnext `json:"vnext"`
 }
 
+// Endpoint implements parser.Endpoint.
+func (c *vlessConfig) Endpoint() (string, int) {
+	return c.Server, c.Port
+}
+
 func (c *vlessConfig) MarshalJSON() ([]byte, error) {
 	user := vlessJSONUser{
 		ID:         c.ID,
@@ -169,13 +195,25 @@ func (c *vlessConfig) MarshalJSON() ([]byte, error) {
 			}
 			tlsSettings["alpn"] = alpnList
 		}
+		if c.Fingerprint != "" {
+			tlsSettings["fingerprint"] = c.Fingerprint
+		}
 		if len(tlsSettings) > 0 {
 			streamSettings.TLSSettings = tlsSettings
 		}
 	case "reality":
-		// Skip REALITY to avoid "empty password" errors - fall back to no security
-		// REALITY requires complex configuration that's not available in URL format
-		break
+		streamSettings.Security = "reality"
+		realitySettings := map[string]interface{}{
+			"show":        false,
+			"publicKey":   c.RealityPublicKey,
+			"serverName":  c.SNI,
+			"shortId":     c.RealityShortID,
+			"fingerprint": c.Fingerprint,
+		}
+		if c.RealitySpiderX != "" {
+			realitySettings["spiderX"] = c.RealitySpiderX
+		}
+		streamSettings.RealitySettings = realitySettings
 	}
 
 	outboundConfig := map[string]interface{}{
@@ -228,9 +266,23 @@ func parseVless(link string) (Config, error) {
 	}
 
 	config.Flow = params.Get("flow")
+	if !validFlows[config.Flow] {
+		return nil, fmt.Errorf("unsupported XTLS flow: %s", config.Flow)
+	}
+
 	config.Security = params.Get("security")
 	config.SNI = params.Get("sni")
 	config.ALPN = params.Get("alpn")
+	config.Fingerprint = params.Get("fp")
+
+	if config.Security == "reality" {
+		config.RealityPublicKey = params.Get("pbk")
+		config.RealityShortID = params.Get("sid")
+		config.RealitySpiderX = params.Get("spx")
+		if config.RealityPublicKey == "" || config.SNI == "" {
+			return nil, fmt.Errorf("%w: REALITY requires pbk and sni", ErrInvalidFormat)
+		}
+	}
 
 	config.Network = params.Get("type")
 	if config.Network == "" {
@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseVless_Reality(t *testing.T) {
+	tests := []struct {
+		name    string
+		link    string
+		wantErr bool
+		// checked only when wantErr is false
+		wantPublicKey string
+		wantShortID   string
+		wantSpiderX   string
+	}{
+		{
+			name:          "full reality params",
+			link:          "vless://uuid-1@example.com:443?security=reality&sni=hidden.example.com&pbk=pubkey123&sid=abcd&fp=chrome&spx=%2F",
+			wantPublicKey: "pubkey123",
+			wantShortID:   "abcd",
+			wantSpiderX:   "/",
+		},
+		{
+			name:          "pbk and sni only, no sid/spx",
+			link:          "vless://uuid-1@example.com:443?security=reality&sni=hidden.example.com&pbk=pubkey123",
+			wantPublicKey: "pubkey123",
+			wantShortID:   "",
+			wantSpiderX:   "",
+		},
+		{
+			name:    "missing pbk",
+			link:    "vless://uuid-1@example.com:443?security=reality&sni=hidden.example.com",
+			wantErr: true,
+		},
+		{
+			name:    "missing sni",
+			link:    "vless://uuid-1@example.com:443?security=reality&pbk=pubkey123",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := parseVless(tc.link)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("parseVless(%q) expected an error, got none", tc.link)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseVless(%q) returned error: %v", tc.link, err)
+			}
+
+			vless, ok := cfg.(*vlessConfig)
+			if !ok {
+				t.Fatalf("parseVless returned %T, want *vlessConfig", cfg)
+			}
+			if vless.RealityPublicKey != tc.wantPublicKey {
+				t.Errorf("RealityPublicKey = %q, want %q", vless.RealityPublicKey, tc.wantPublicKey)
+			}
+			if vless.RealityShortID != tc.wantShortID {
+				t.Errorf("RealityShortID = %q, want %q", vless.RealityShortID, tc.wantShortID)
+			}
+			if vless.RealitySpiderX != tc.wantSpiderX {
+				t.Errorf("RealitySpiderX = %q, want %q", vless.RealitySpiderX, tc.wantSpiderX)
+			}
+
+			raw, err := cfg.MarshalJSON()
+			if err != nil {
+				t.Fatalf("MarshalJSON: %v", err)
+			}
+			var decoded struct {
+				StreamSettings struct {
+					Security        string                 `json:"security"`
+					RealitySettings map[string]interface{} `json:"realitySettings"`
+				} `json:"streamSettings"`
+			}
+			if err := json.Unmarshal(raw, &decoded); err != nil {
+				t.Fatalf("unmarshal outbound config: %v", err)
+			}
+			if decoded.StreamSettings.Security != "reality" {
+				t.Errorf("streamSettings.security = %q, want %q", decoded.StreamSettings.Security, "reality")
+			}
+			if decoded.StreamSettings.RealitySettings["publicKey"] != tc.wantPublicKey {
+				t.Errorf("realitySettings.publicKey = %v, want %q", decoded.StreamSettings.RealitySettings["publicKey"], tc.wantPublicKey)
+			}
+		})
+	}
+}
+
+func TestParseVless_FlowValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		link    string
+		wantErr bool
+	}{
+		{
+			name: "no flow is valid",
+			link: "vless://uuid-1@example.com:443",
+		},
+		{
+			name: "xtls-rprx-vision is valid",
+			link: "vless://uuid-1@example.com:443?flow=xtls-rprx-vision",
+		},
+		{
+			name: "xtls-rprx-vision-udp443 is valid",
+			link: "vless://uuid-1@example.com:443?flow=xtls-rprx-vision-udp443",
+		},
+		{
+			name:    "unknown flow is rejected",
+			link:    "vless://uuid-1@example.com:443?flow=xtls-rprx-splice",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseVless(tc.link)
+			if tc.wantErr && err == nil {
+				t.Fatalf("parseVless(%q) expected an error, got none", tc.link)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("parseVless(%q) returned unexpected error: %v", tc.link, err)
+			}
+		})
+	}
+}
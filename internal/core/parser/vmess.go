@@ -68,6 +68,11 @@ type vmessJSONSettings struct {
 	Vnext []vmessJSONVnext `json:"vnext"`
 }
 
+// Endpoint implements parser.Endpoint.
+func (c *vmessConfig) Endpoint() (string, int) {
+	return c.Server, c.Port
+}
+
 func (c *vmessConfig) MarshalJSON() ([]byte, error) {
 	user := vmessJSONUser{
 		ID:       c.ID,
@@ -0,0 +1,212 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type wireguardConfig struct {
+	Raw          string   `json:"-"`
+	Server       string   `json:"server"`
+	Port         int      `json:"port"`
+	PrivateKey   string   `json:"privateKey"`
+	PublicKey    string   `json:"publicKey"`
+	PreSharedKey string   `json:"preSharedKey,omitempty"`
+	Address      []string `json:"address"`
+	MTU          int      `json:"mtu,omitempty"`
+	Reserved     []int    `json:"reserved,omitempty"`
+	Remark       string   `json:"remark,omitempty"`
+}
+
+type wireguardJSONPeer struct {
+	PublicKey    string   `json:"publicKey"`
+	PreSharedKey string   `json:"preSharedKey,omitempty"`
+	Endpoint     string   `json:"endpoint"`
+	AllowedIPs   []string `json:"allowedIPs,omitempty"`
+}
+
+type wireguardJSONSettings struct {
+	SecretKey string              `json:"secretKey"`
+	Address   []string            `json:"address"`
+	Peers     []wireguardJSONPeer `json:"peers"`
+	MTU       int                 `json:"mtu,omitempty"`
+	Reserved  []int               `json:"reserved,omitempty"`
+}
+
+// Endpoint implements parser.Endpoint.
+func (c *wireguardConfig) Endpoint() (string, int) {
+	return c.Server, c.Port
+}
+
+func (c *wireguardConfig) MarshalJSON() ([]byte, error) {
+	address := c.Address
+	if len(address) == 0 {
+		address = []string{"0.0.0.0/32"}
+	}
+
+	settings := wireguardJSONSettings{
+		SecretKey: c.PrivateKey,
+		Address:   address,
+		Peers: []wireguardJSONPeer{{
+			PublicKey:    c.PublicKey,
+			PreSharedKey: c.PreSharedKey,
+			Endpoint:     net.JoinHostPort(c.Server, strconv.Itoa(c.Port)),
+			AllowedIPs:   []string{"0.0.0.0/0", "::/0"},
+		}},
+		MTU:      c.MTU,
+		Reserved: c.Reserved,
+	}
+
+	outboundConfig := map[string]interface{}{
+		"protocol": "wireguard",
+		"settings": settings,
+	}
+
+	return json.Marshal(outboundConfig)
+}
+
+// parseWireGuard parses either the wireguard:// URI form used by several
+// clients (wireguard://privateKey@host:port?publickey=...&presharedkey=...
+// &address=10.0.0.2/32&mtu=1420&reserved=1,2,3#remark) or a raw
+// [Interface]/[Peer] INI config, dispatched by Parser.Parse based on
+// whether link has a "wireguard://" scheme or a "[Interface]" prefix.
+func parseWireGuard(link string) (Config, error) {
+	if strings.HasPrefix(strings.TrimSpace(link), "[Interface]") {
+		return parseWireGuardINI(link)
+	}
+	return parseWireGuardURI(link)
+}
+
+func parseWireGuardURI(link string) (Config, error) {
+	parsedURL, err := url.Parse(link)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WireGuard link format: %v", err)
+	}
+
+	config := &wireguardConfig{
+		Raw:        link,
+		PrivateKey: parsedURL.User.Username(),
+	}
+
+	host := parsedURL.Hostname()
+	if host == "" {
+		return nil, fmt.Errorf("server address is required")
+	}
+	config.Server = host
+
+	if portStr := parsedURL.Port(); portStr != "" {
+		if config.Port, err = strconv.Atoi(portStr); err != nil {
+			return nil, fmt.Errorf("invalid port: %v", err)
+		}
+	} else {
+		config.Port = DefaultPort("wireguard")
+	}
+
+	params := parsedURL.Query()
+	config.PublicKey = params.Get("publickey")
+	config.PreSharedKey = params.Get("presharedkey")
+	if address := params.Get("address"); address != "" {
+		config.Address = strings.Split(address, ",")
+	}
+	if mtu := params.Get("mtu"); mtu != "" {
+		config.MTU, _ = strconv.Atoi(mtu)
+	}
+	if reserved := params.Get("reserved"); reserved != "" {
+		for _, part := range strings.Split(reserved, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return nil, fmt.Errorf("invalid reserved value %q: %v", part, err)
+			}
+			config.Reserved = append(config.Reserved, n)
+		}
+	}
+	if parsedURL.Fragment != "" {
+		config.Remark, _ = url.QueryUnescape(parsedURL.Fragment)
+	}
+
+	if config.PrivateKey == "" {
+		return nil, fmt.Errorf("private key is required")
+	}
+	if config.PublicKey == "" {
+		return nil, fmt.Errorf("publickey is required")
+	}
+
+	return config, nil
+}
+
+// parseWireGuardINI parses a raw WireGuard config file: an [Interface]
+// section (PrivateKey, Address, ...) and a [Peer] section (PublicKey,
+// PresharedKey, Endpoint, ...).
+func parseWireGuardINI(link string) (Config, error) {
+	config := &wireguardConfig{Raw: link}
+
+	section := ""
+	for _, line := range strings.Split(link, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.ToLower(strings.Trim(line, "[]"))
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch section {
+		case "interface":
+			switch key {
+			case "privatekey":
+				config.PrivateKey = value
+			case "address":
+				config.Address = strings.Split(value, ",")
+				for i := range config.Address {
+					config.Address[i] = strings.TrimSpace(config.Address[i])
+				}
+			case "mtu":
+				config.MTU, _ = strconv.Atoi(value)
+			}
+		case "peer":
+			switch key {
+			case "publickey":
+				config.PublicKey = value
+			case "presharedkey":
+				config.PreSharedKey = value
+			case "endpoint":
+				host, portStr, err := net.SplitHostPort(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid Endpoint %q: %v", value, err)
+				}
+				config.Server = host
+				config.Port, err = strconv.Atoi(portStr)
+				if err != nil {
+					return nil, fmt.Errorf("invalid Endpoint port %q: %v", portStr, err)
+				}
+			}
+		}
+	}
+
+	if config.PrivateKey == "" {
+		return nil, fmt.Errorf("private key is required")
+	}
+	if config.PublicKey == "" {
+		return nil, fmt.Errorf("publickey is required")
+	}
+	if config.Server == "" {
+		return nil, fmt.Errorf("endpoint is required")
+	}
+	if config.Port == 0 {
+		config.Port = DefaultPort("wireguard")
+	}
+
+	return config, nil
+}
@@ -3,12 +3,9 @@ package core
 import (
 	"encoding/base64"
 	"encoding/json"
-	"fmt"
 	"net"
-	"net/http"
 	"net/url"
 	"strings"
-	"time"
 
 	"github.com/enescakir/emoji"
 )
@@ -21,11 +18,6 @@ var protocolEmojis = map[string]emoji.Emoji{
 	"ss":     emoji.Locked,
 }
 
-type CountryResponse struct {
-	IP      string `json:"ip"`
-	Country string `json:"country"`
-}
-
 type RemarkTemplate struct {
 	OrgName      string
 	Separator    string
@@ -128,7 +120,7 @@ func (c *Core) generateRemark(server, protocol string, tmpl RemarkTemplate) stri
 	}
 
 	if tmpl.ShowCountry {
-		if countryCode := getCountryFromServer(server); countryCode != "" {
+		if countryCode := c.countryCode(server); countryCode != "" {
 			countryFlag, err := emoji.CountryFlag(strings.ToLower(countryCode))
 			if err == nil {
 				parts = append(parts, countryFlag.String())
@@ -141,36 +133,6 @@ func (c *Core) generateRemark(server, protocol string, tmpl RemarkTemplate) stri
 	return strings.Join(parts, tmpl.Separator)
 }
 
-func getCountryFromServer(server string) string {
-	if server == "" {
-		return ""
-	}
-
-	ip := server
-	if !net.ParseIP(server).IsUnspecified() {
-		if ips, err := net.LookupIP(server); err == nil && len(ips) > 0 {
-			ip = ips[0].String()
-		}
-	}
-
-	resp, err := (&http.Client{Timeout: 5 * time.Second}).Get(fmt.Sprintf("https://api.country.is/%s", ip))
-	if err != nil {
-		return ""
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return ""
-	}
-
-	var countryResp CountryResponse
-	if err := json.NewDecoder(resp.Body).Decode(&countryResp); err != nil {
-		return ""
-	}
-
-	return countryResp.Country
-}
-
 func extractServerFromURL(config string) string {
 	// Remove protocol
 	parts := strings.SplitN(config, "://", 2)
@@ -0,0 +1,210 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+func newTestKey(seed byte) []byte {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = seed + byte(i)
+	}
+	return key
+}
+
+func TestEncryptDecrypt_RoundTrip(t *testing.T) {
+	key := newTestKey(0)
+	plaintext := []byte("hello, namira")
+
+	encrypted, err := Encrypt(plaintext, key)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	decrypted, err := Decrypt(encrypted, key)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("decrypted = %q, want %q", decrypted, plaintext)
+	}
+}
+
+func TestDecrypt_WrongKeyFails(t *testing.T) {
+	encrypted, err := Encrypt([]byte("secret"), newTestKey(0))
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if _, err := Decrypt(encrypted, newTestKey(1)); err == nil {
+		t.Fatalf("Decrypt with wrong key succeeded, want an error")
+	}
+}
+
+func TestDecrypt_TooShortFails(t *testing.T) {
+	if _, err := Decrypt([]byte("short"), newTestKey(0)); err == nil {
+		t.Fatalf("Decrypt of truncated data succeeded, want an error")
+	}
+}
+
+func TestKeyring_EncryptDecrypt_RoundTrip(t *testing.T) {
+	keys := map[string][]byte{"k1": newTestKey(0)}
+	ring, err := NewKeyring(keys, "k1")
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+
+	plaintext := []byte(`{"configs":["vless://example"]}`)
+	sealed, err := ring.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	decrypted, err := ring.Decrypt(sealed)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("decrypted = %q, want %q", decrypted, plaintext)
+	}
+}
+
+func TestKeyring_EncryptDEK_RoundTrip(t *testing.T) {
+	keys := map[string][]byte{"k1": newTestKey(0)}
+	ring, err := NewKeyring(keys, "k1")
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+
+	plaintext := []byte(`{"configs":["vless://example"]}`)
+	sealed, err := ring.EncryptDEK(plaintext)
+	if err != nil {
+		t.Fatalf("EncryptDEK: %v", err)
+	}
+
+	decrypted, err := ring.Decrypt(sealed)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("decrypted = %q, want %q", decrypted, plaintext)
+	}
+}
+
+// TestKeyring_Rotation encrypts under an old key, rotates the active writer
+// key, and confirms data sealed under either key id still decrypts through
+// the post-rotation keyring — the whole point of key_id being carried in the
+// envelope header.
+func TestKeyring_Rotation(t *testing.T) {
+	keys := map[string][]byte{"k1": newTestKey(0)}
+	before, err := NewKeyring(keys, "k1")
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+
+	plaintext := []byte("encrypted before rotation")
+	sealed, err := before.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	rotatedKeys := map[string][]byte{"k1": newTestKey(0), "k2": newTestKey(1)}
+	after, err := NewKeyring(rotatedKeys, "k2")
+	if err != nil {
+		t.Fatalf("NewKeyring after rotation: %v", err)
+	}
+
+	decrypted, err := after.Decrypt(sealed)
+	if err != nil {
+		t.Fatalf("Decrypt old-key data after rotation: %v", err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("decrypted = %q, want %q", decrypted, plaintext)
+	}
+
+	newSealed, err := after.Encrypt([]byte("encrypted after rotation"))
+	if err != nil {
+		t.Fatalf("Encrypt after rotation: %v", err)
+	}
+	if _, err := before.Decrypt(newSealed); err == nil {
+		t.Fatalf("expected decrypt under pre-rotation keyring (missing k2) to fail")
+	}
+}
+
+// TestKeyring_Decrypt_LegacyFallback confirms data encrypted before the
+// keyring existed (raw Encrypt/Decrypt, no header) still decrypts by trying
+// every key in the ring.
+func TestKeyring_Decrypt_LegacyFallback(t *testing.T) {
+	key := newTestKey(0)
+	legacy, err := Encrypt([]byte("pre-keyring data"), key)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	ring, err := NewKeyring(map[string][]byte{"k1": key}, "k1")
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+
+	decrypted, err := ring.Decrypt(legacy)
+	if err != nil {
+		t.Fatalf("Decrypt legacy data: %v", err)
+	}
+	if string(decrypted) != "pre-keyring data" {
+		t.Fatalf("decrypted = %q, want %q", decrypted, "pre-keyring data")
+	}
+}
+
+func TestKeyring_Decrypt_UnknownKeyID(t *testing.T) {
+	ring1, err := NewKeyring(map[string][]byte{"k1": newTestKey(0)}, "k1")
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	sealed, err := ring1.Encrypt([]byte("data"))
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	ring2, err := NewKeyring(map[string][]byte{"k2": newTestKey(1)}, "k2")
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	if _, err := ring2.Decrypt(sealed); err == nil {
+		t.Fatalf("expected decrypt with unknown key id to fail")
+	}
+}
+
+func TestNewKeyring_RequiresActiveKeyPresent(t *testing.T) {
+	if _, err := NewKeyring(map[string][]byte{"k1": newTestKey(0)}, "missing"); err == nil {
+		t.Fatalf("expected an error when activeID is absent from keys")
+	}
+	if _, err := NewKeyring(map[string][]byte{}, "k1"); err == nil {
+		t.Fatalf("expected an error for an empty keyring")
+	}
+}
+
+func TestParseKeyring(t *testing.T) {
+	spec := "k1:0001020304050607080910111213141516171819202122232425262728293031,k2:0102030405060708091011121314151617181920212223242526272829303132"
+	ring, err := ParseKeyring(spec, "k2")
+	if err != nil {
+		t.Fatalf("ParseKeyring: %v", err)
+	}
+
+	sealed, err := ring.Encrypt([]byte("data"))
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	decrypted, err := ring.Decrypt(sealed)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if string(decrypted) != "data" {
+		t.Fatalf("decrypted = %q, want %q", decrypted, "data")
+	}
+}
+
+func TestParseKeyring_InvalidEntry(t *testing.T) {
+	if _, err := ParseKeyring("not-a-valid-entry", "k1"); err == nil {
+		t.Fatalf("expected an error for a malformed ENCRYPTION_KEYS entry")
+	}
+}
@@ -0,0 +1,181 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	keyringMagic = "NMC1"
+	keyIDSize    = 8
+
+	formatRaw     byte = 1 // body sealed directly under the named keyring key
+	formatWrapped byte = 2 // body sealed under an ephemeral data key, itself wrapped by the named (KEK) keyring key
+)
+
+// Keyring is a set of AES-256 keys addressable by a short id, so
+// ENCRYPTION_KEY can be rotated without invalidating results encrypted under
+// a previous key: old ids stay in the keyring for decryption, only ActiveID's
+// key is used to encrypt new data.
+type Keyring struct {
+	keys     map[string][]byte
+	activeID string
+}
+
+// NewKeyring builds a Keyring from id->key, with activeID naming the key
+// new data is encrypted under. Older ids remain available for Decrypt.
+func NewKeyring(keys map[string][]byte, activeID string) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("keyring must have at least one key")
+	}
+	if _, ok := keys[activeID]; !ok {
+		return nil, fmt.Errorf("active key id %q not present in keyring", activeID)
+	}
+	return &Keyring{keys: keys, activeID: activeID}, nil
+}
+
+// ParseKeyring builds a Keyring from ENCRYPTION_KEYS-style "id:hex,id:hex"
+// pairs, with activeID (ENCRYPTION_KEY_ACTIVE) naming the writer key.
+func ParseKeyring(spec, activeID string) (*Keyring, error) {
+	keys := make(map[string][]byte)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		id, hexKey, found := strings.Cut(pair, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid ENCRYPTION_KEYS entry %q: want id:hex", pair)
+		}
+		key, err := hex.DecodeString(hexKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key for id %q: %w", id, err)
+		}
+		keys[id] = key
+	}
+	return NewKeyring(keys, activeID)
+}
+
+func encodeKeyID(id string) [keyIDSize]byte {
+	var buf [keyIDSize]byte
+	copy(buf[:], id)
+	return buf
+}
+
+func decodeKeyID(buf []byte) string {
+	return strings.TrimRight(string(buf), "\x00")
+}
+
+func (k *Keyring) header(format byte, keyID string) []byte {
+	id := encodeKeyID(keyID)
+	header := make([]byte, 0, len(keyringMagic)+1+keyIDSize)
+	header = append(header, keyringMagic...)
+	header = append(header, format)
+	header = append(header, id[:]...)
+	return header
+}
+
+// Encrypt seals data under the keyring's active key, producing
+// magic + format + key_id + nonce + ciphertext.
+func (k *Keyring) Encrypt(data []byte) ([]byte, error) {
+	sealed, err := Encrypt(data, k.keys[k.activeID])
+	if err != nil {
+		return nil, err
+	}
+	return append(k.header(formatRaw, k.activeID), sealed...), nil
+}
+
+// EncryptDEK encrypts data under a freshly-generated 32-byte data key (DEK),
+// then wraps the DEK with the keyring's active key (KEK) and stores the
+// wrapped DEK alongside the body. This lets a per-job DEK be escrowed or
+// revoked independently of the KEK, without re-encrypting the rest of the
+// archive under a new key.
+func (k *Keyring) EncryptDEK(data []byte) ([]byte, error) {
+	dek := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+		return nil, fmt.Errorf("generate data key: %w", err)
+	}
+
+	wrappedDEK, err := Encrypt(dek, k.keys[k.activeID])
+	if err != nil {
+		return nil, fmt.Errorf("wrap data key: %w", err)
+	}
+
+	body, err := Encrypt(data, dek)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt body: %w", err)
+	}
+
+	out := k.header(formatWrapped, k.activeID)
+	var lenBuf [2]byte
+	binary.BigEndian.PutUint16(lenBuf[:], uint16(len(wrappedDEK)))
+	out = append(out, lenBuf[:]...)
+	out = append(out, wrappedDEK...)
+	out = append(out, body...)
+	return out, nil
+}
+
+// Decrypt opens data sealed by Encrypt or EncryptDEK under any key currently
+// in the keyring. Data with no keyring header (the pre-rotation raw-nonce
+// format) falls back to trying every key in the keyring, so files encrypted
+// before the keyring existed still decrypt.
+func (k *Keyring) Decrypt(data []byte) ([]byte, error) {
+	if len(data) < len(keyringMagic)+1+keyIDSize || string(data[:len(keyringMagic)]) != keyringMagic {
+		return k.decryptLegacy(data)
+	}
+
+	offset := len(keyringMagic)
+	format := data[offset]
+	offset++
+	keyID := decodeKeyID(data[offset : offset+keyIDSize])
+	offset += keyIDSize
+
+	kek, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", keyID)
+	}
+
+	switch format {
+	case formatRaw:
+		return Decrypt(data[offset:], kek)
+	case formatWrapped:
+		if offset+2 > len(data) {
+			return nil, errors.New("truncated wrapped-key header")
+		}
+		wrappedLen := int(binary.BigEndian.Uint16(data[offset:]))
+		offset += 2
+		if offset+wrappedLen > len(data) {
+			return nil, errors.New("truncated wrapped data key")
+		}
+		wrappedDEK := data[offset : offset+wrappedLen]
+		offset += wrappedLen
+
+		dek, err := Decrypt(wrappedDEK, kek)
+		if err != nil {
+			return nil, fmt.Errorf("unwrap data key: %w", err)
+		}
+		return Decrypt(data[offset:], dek)
+	default:
+		return nil, fmt.Errorf("unknown envelope format %d", format)
+	}
+}
+
+func (k *Keyring) decryptLegacy(data []byte) ([]byte, error) {
+	var lastErr error
+	for _, key := range k.keys {
+		if plaintext, err := Decrypt(data, key); err == nil {
+			return plaintext, nil
+		} else {
+			lastErr = err
+		}
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no keys configured")
+	}
+	return nil, fmt.Errorf("legacy decrypt failed: %w", lastErr)
+}
@@ -0,0 +1,23 @@
+package export
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/NaMiraNet/namira-core/internal/core"
+)
+
+// renderBase64 concatenates every successful result's raw link, newline
+// separated, and standard-base64 encodes the blob — the V2RayN subscription
+// convention.
+func renderBase64(results []core.CheckResult) []byte {
+	lines := make([]string, 0, len(results))
+	for _, result := range results {
+		if result.Error != "" || result.Raw == "" {
+			continue
+		}
+		lines = append(lines, result.Raw)
+	}
+
+	return []byte(base64.StdEncoding.EncodeToString([]byte(strings.Join(lines, "\n"))))
+}
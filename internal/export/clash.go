@@ -0,0 +1,143 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/NaMiraNet/namira-core/internal/core"
+	"gopkg.in/yaml.v3"
+)
+
+// clashDoc is the minimal Clash config shape export cares about: just the
+// proxies list, since this is used as a subscription fragment rather than a
+// full client config.
+type clashDoc struct {
+	Proxies []map[string]interface{} `yaml:"proxies"`
+}
+
+// renderClash builds a Clash `proxies:` YAML document from results' parsed
+// vmess/vless/trojan/ss links.
+func renderClash(results []core.CheckResult) ([]byte, error) {
+	doc := clashDoc{Proxies: make([]map[string]interface{}, 0, len(results))}
+	names := make(map[string]int)
+
+	for _, result := range results {
+		if result.Error != "" || result.Raw == "" {
+			continue
+		}
+
+		p, err := parseProxyURI(result.Raw)
+		if err != nil {
+			continue
+		}
+
+		doc.Proxies = append(doc.Proxies, clashProxyDict(p, uniqueName(p, result, names)))
+	}
+
+	return yaml.Marshal(doc)
+}
+
+// uniqueName picks a display name for p, preferring the core-generated
+// remark, then falling back to the raw link's own name, then to
+// server:port, de-duplicated since Clash requires unique proxy names.
+func uniqueName(p *proxy, result core.CheckResult, seen map[string]int) string {
+	name := result.Remark
+	if name == "" {
+		name = p.Name
+	}
+	if name == "" {
+		name = fmt.Sprintf("%s-%d", p.Server, p.Port)
+	}
+
+	seen[name]++
+	if seen[name] > 1 {
+		name = fmt.Sprintf("%s (%d)", name, seen[name])
+	}
+	return name
+}
+
+func clashProxyDict(p *proxy, name string) map[string]interface{} {
+	dict := map[string]interface{}{
+		"name":   name,
+		"type":   p.Type,
+		"server": p.Server,
+		"port":   p.Port,
+		"udp":    true,
+	}
+
+	switch p.Type {
+	case "vmess":
+		dict["uuid"] = p.UUID
+		dict["alterId"] = p.AlterID
+		dict["cipher"] = p.Cipher
+	case "vless":
+		dict["uuid"] = p.UUID
+		if p.Flow != "" {
+			dict["flow"] = p.Flow
+		}
+	case "trojan":
+		dict["password"] = p.Password
+	case "ss":
+		dict["cipher"] = p.Cipher
+		dict["password"] = p.Password
+	}
+
+	if p.Type == "vmess" || p.Type == "vless" {
+		dict["tls"] = p.TLS
+		if p.TLS && p.SNI != "" {
+			dict["servername"] = p.SNI
+		}
+		if p.Network != "" && p.Network != "tcp" {
+			dict["network"] = p.Network
+		}
+		applyClashTransportOpts(dict, p)
+	} else if p.Type == "trojan" {
+		if p.SNI != "" {
+			dict["sni"] = p.SNI
+		}
+		if p.Network != "" && p.Network != "tcp" {
+			dict["network"] = p.Network
+			applyClashTransportOpts(dict, p)
+		}
+	}
+
+	return dict
+}
+
+// applyClashTransportOpts sets the ws-opts/grpc-opts/h2-opts block matching
+// p.Network, mirroring the stream-settings switch in internal/core/parser's
+// vmess/vless/trojan MarshalJSON, just targeting Clash's option names
+// instead of Xray's.
+func applyClashTransportOpts(dict map[string]interface{}, p *proxy) {
+	switch p.Network {
+	case "ws":
+		opts := map[string]interface{}{}
+		if p.Path != "" {
+			opts["path"] = p.Path
+		}
+		if p.Host != "" {
+			opts["headers"] = map[string]string{"Host": p.Host}
+		}
+		if len(opts) > 0 {
+			dict["ws-opts"] = opts
+		}
+	case "grpc":
+		opts := map[string]interface{}{}
+		if p.Path != "" {
+			opts["grpc-service-name"] = p.Path
+		}
+		if len(opts) > 0 {
+			dict["grpc-opts"] = opts
+		}
+	case "h2":
+		opts := map[string]interface{}{}
+		if p.Path != "" {
+			opts["path"] = p.Path
+		}
+		if p.Host != "" {
+			opts["host"] = []string{p.Host}
+		}
+		if len(opts) > 0 {
+			dict["h2-opts"] = opts
+		}
+	}
+}
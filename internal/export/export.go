@@ -0,0 +1,47 @@
+// Package export converts successful namira-core check results into the
+// subscription formats real VPN clients consume, as opposed to the
+// human-oriented table/json/csv output internal/cli.OutputManager produces.
+package export
+
+import (
+	"fmt"
+
+	"github.com/NaMiraNet/namira-core/internal/core"
+)
+
+// Format is a subscription output format Render knows how to produce.
+type Format string
+
+const (
+	FormatBase64  Format = "base64"
+	FormatClash   Format = "clash"
+	FormatSingBox Format = "sing-box"
+)
+
+// Supported reports whether format is one Render accepts, so callers (CLI
+// flag validation, the API's ?format= query param) can reject an unknown
+// format before doing any work.
+func Supported(format Format) bool {
+	switch format {
+	case FormatBase64, FormatClash, FormatSingBox:
+		return true
+	default:
+		return false
+	}
+}
+
+// Render builds the requested subscription document from results, including
+// only entries where Error == "". Entries whose Raw link can't be parsed
+// into a proxy are skipped rather than failing the whole export.
+func Render(results []core.CheckResult, format Format) ([]byte, error) {
+	switch format {
+	case FormatBase64:
+		return renderBase64(results), nil
+	case FormatClash:
+		return renderClash(results)
+	case FormatSingBox:
+		return renderSingBox(results)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
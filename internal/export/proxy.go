@@ -0,0 +1,266 @@
+package export
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// proxy is a protocol-agnostic view of a parsed subscription link, just rich
+// enough to build both a Clash proxy dict and a sing-box outbound. It's kept
+// separate from internal/core/parser's Config types, which marshal straight
+// to an Xray outbound and don't expose the plain fields (cipher, network
+// options) Clash/sing-box documents need.
+type proxy struct {
+	Name     string
+	Type     string // vmess, vless, trojan, ss
+	Server   string
+	Port     int
+	UUID     string // vmess/vless
+	AlterID  int    // vmess
+	Cipher   string // vmess security / ss method
+	Password string // trojan/ss
+	Flow     string // vless
+	Network  string // ws, grpc, tcp, h2, ""
+	Path     string
+	Host     string
+	TLS      bool
+	SNI      string
+}
+
+// parseProxyURI converts a raw vmess/vless/trojan/ss subscription link into
+// a proxy, dispatching on its scheme the same way internal/core/parser does.
+func parseProxyURI(raw string) (*proxy, error) {
+	scheme, _, ok := strings.Cut(raw, "://")
+	if !ok {
+		return nil, fmt.Errorf("invalid link: missing scheme")
+	}
+
+	switch strings.ToLower(scheme) {
+	case "vmess":
+		return parseVMessURI(raw)
+	case "vless":
+		return parseVLESSURI(raw)
+	case "trojan":
+		return parseTrojanURI(raw)
+	case "ss":
+		return parseSSURI(raw)
+	default:
+		return nil, fmt.Errorf("unsupported protocol for export: %s", scheme)
+	}
+}
+
+type vmessLinkFields struct {
+	PS   string `json:"ps"`
+	Add  string `json:"add"`
+	Port string `json:"port"`
+	ID   string `json:"id"`
+	Aid  string `json:"aid"`
+	Scy  string `json:"scy"`
+	Net  string `json:"net"`
+	Host string `json:"host"`
+	Path string `json:"path"`
+	TLS  string `json:"tls"`
+	SNI  string `json:"sni"`
+}
+
+func parseVMessURI(raw string) (*proxy, error) {
+	encoded := strings.TrimPrefix(raw, "vmess://")
+
+	var data []byte
+	var err error
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		if data, err = enc.DecodeString(encoded); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid vmess link: %w", err)
+	}
+
+	var fields vmessLinkFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("invalid vmess link: %w", err)
+	}
+
+	port, err := strconv.Atoi(fields.Port)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vmess port: %w", err)
+	}
+
+	cipher := fields.Scy
+	if cipher == "" {
+		cipher = "auto"
+	}
+
+	return &proxy{
+		Name:    fields.PS,
+		Type:    "vmess",
+		Server:  fields.Add,
+		Port:    port,
+		UUID:    fields.ID,
+		AlterID: atoiOrZero(fields.Aid),
+		Cipher:  cipher,
+		Network: fields.Net,
+		Host:    fields.Host,
+		Path:    fields.Path,
+		TLS:     fields.TLS == "tls",
+		SNI:     fields.SNI,
+	}, nil
+}
+
+func parseVLESSURI(raw string) (*proxy, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vless link: %w", err)
+	}
+
+	host, portStr, err := net.SplitHostPort(parsed.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vless link: %w", err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vless port: %w", err)
+	}
+
+	params := parsed.Query()
+
+	var uuid string
+	if parsed.User != nil {
+		uuid = parsed.User.Username()
+	}
+
+	remark, _ := url.QueryUnescape(parsed.Fragment)
+	security := params.Get("security")
+
+	return &proxy{
+		Name:    remark,
+		Type:    "vless",
+		Server:  host,
+		Port:    port,
+		UUID:    uuid,
+		Flow:    params.Get("flow"),
+		Network: params.Get("type"),
+		Host:    params.Get("host"),
+		Path:    params.Get("path"),
+		TLS:     security == "tls" || security == "reality",
+		SNI:     params.Get("sni"),
+	}, nil
+}
+
+func parseTrojanURI(raw string) (*proxy, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trojan link: %w", err)
+	}
+
+	host, portStr, err := net.SplitHostPort(parsed.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trojan link: %w", err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trojan port: %w", err)
+	}
+
+	params := parsed.Query()
+	remark, _ := url.QueryUnescape(parsed.Fragment)
+
+	return &proxy{
+		Name:     remark,
+		Type:     "trojan",
+		Server:   host,
+		Port:     port,
+		Password: parsed.User.Username(),
+		Network:  params.Get("type"),
+		Host:     params.Get("host"),
+		Path:     params.Get("path"),
+		TLS:      true,
+		SNI:      params.Get("sni"),
+	}, nil
+}
+
+func parseSSURI(raw string) (*proxy, error) {
+	link := strings.TrimPrefix(raw, "ss://")
+
+	var remark string
+	if body, frag, found := strings.Cut(link, "#"); found {
+		remark, _ = url.QueryUnescape(frag)
+		link = body
+	}
+	if body, _, found := strings.Cut(link, "?"); found {
+		link = body
+	}
+
+	var method, password, server string
+	if atIndex := strings.LastIndex(link, "@"); atIndex != -1 {
+		authPart, serverPart := link[:atIndex], link[atIndex+1:]
+		decoded, err := decodeSSB64(authPart)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ss link: %w", err)
+		}
+		var ok bool
+		method, password, ok = strings.Cut(decoded, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid ss link: malformed credentials")
+		}
+		server = serverPart
+	} else {
+		decoded, err := decodeSSB64(link)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ss link: %w", err)
+		}
+		atIndex := strings.LastIndex(decoded, "@")
+		if atIndex == -1 {
+			return nil, fmt.Errorf("invalid ss link: missing server")
+		}
+		var ok bool
+		method, password, ok = strings.Cut(decoded[:atIndex], ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid ss link: malformed credentials")
+		}
+		server = decoded[atIndex+1:]
+	}
+
+	host, portStr, err := net.SplitHostPort(server)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ss link: %w", err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ss port: %w", err)
+	}
+
+	return &proxy{
+		Name:     remark,
+		Type:     "ss",
+		Server:   host,
+		Port:     port,
+		Cipher:   method,
+		Password: password,
+	}, nil
+}
+
+// decodeSSB64 tries the base64 alphabets SIP002 implementations are seen to
+// emit, falling back to treating s as already-plain text.
+func decodeSSB64(s string) (string, error) {
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		if decoded, err := enc.DecodeString(s); err == nil {
+			return string(decoded), nil
+		}
+	}
+	if unescaped, err := url.QueryUnescape(s); err == nil {
+		return unescaped, nil
+	}
+	return "", fmt.Errorf("unable to decode")
+}
+
+func atoiOrZero(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,122 @@
+package export
+
+import (
+	"encoding/json"
+
+	"github.com/NaMiraNet/namira-core/internal/core"
+)
+
+// singBoxDoc is the minimal sing-box config shape export cares about: just
+// the outbounds list, since this is used as a subscription fragment rather
+// than a full client config.
+type singBoxDoc struct {
+	Outbounds []map[string]interface{} `json:"outbounds"`
+}
+
+// renderSingBox builds a `{"outbounds":[...]}` sing-box document from
+// results' parsed vmess/vless/trojan/ss links.
+func renderSingBox(results []core.CheckResult) ([]byte, error) {
+	doc := singBoxDoc{Outbounds: make([]map[string]interface{}, 0, len(results))}
+	names := make(map[string]int)
+
+	for _, result := range results {
+		if result.Error != "" || result.Raw == "" {
+			continue
+		}
+
+		p, err := parseProxyURI(result.Raw)
+		if err != nil {
+			continue
+		}
+
+		doc.Outbounds = append(doc.Outbounds, singBoxOutbound(p, uniqueName(p, result, names)))
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func singBoxOutbound(p *proxy, tag string) map[string]interface{} {
+	outbound := map[string]interface{}{
+		"tag":         tag,
+		"type":        p.Type,
+		"server":      p.Server,
+		"server_port": p.Port,
+	}
+
+	switch p.Type {
+	case "vmess":
+		outbound["uuid"] = p.UUID
+		outbound["alter_id"] = p.AlterID
+		outbound["security"] = p.Cipher
+	case "vless":
+		outbound["uuid"] = p.UUID
+		if p.Flow != "" {
+			outbound["flow"] = p.Flow
+		}
+	case "trojan":
+		outbound["password"] = p.Password
+	case "ss":
+		outbound["method"] = p.Cipher
+		outbound["password"] = p.Password
+	}
+
+	if tls := singBoxTLS(p); tls != nil {
+		outbound["tls"] = tls
+	}
+	if transport := singBoxTransport(p); transport != nil {
+		outbound["transport"] = transport
+	}
+
+	return outbound
+}
+
+func singBoxTLS(p *proxy) map[string]interface{} {
+	if p.Type == "trojan" {
+		tls := map[string]interface{}{"enabled": true}
+		if p.SNI != "" {
+			tls["server_name"] = p.SNI
+		}
+		return tls
+	}
+	if !p.TLS {
+		return nil
+	}
+	tls := map[string]interface{}{"enabled": true}
+	if p.SNI != "" {
+		tls["server_name"] = p.SNI
+	}
+	return tls
+}
+
+// singBoxTransport sets the sing-box v2ray-transport block matching
+// p.Network, mirroring applyClashTransportOpts but in sing-box's own shape.
+func singBoxTransport(p *proxy) map[string]interface{} {
+	switch p.Network {
+	case "ws":
+		transport := map[string]interface{}{"type": "ws"}
+		if p.Path != "" {
+			transport["path"] = p.Path
+		}
+		if p.Host != "" {
+			transport["headers"] = map[string]string{"Host": p.Host}
+		}
+		return transport
+	case "grpc":
+		transport := map[string]interface{}{"type": "grpc"}
+		if p.Path != "" {
+			transport["service_name"] = p.Path
+		}
+		return transport
+	case "h2":
+		transport := map[string]interface{}{"type": "http"}
+		if p.Path != "" {
+			transport["path"] = p.Path
+		}
+		if p.Host != "" {
+			transport["host"] = []string{p.Host}
+		}
+		return transport
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,247 @@
+// Package faultproxy provides a pluggable net.Dial interceptor for injecting
+// network faults — added latency, jitter, packet loss, resets, bandwidth
+// caps, and blackholes — keyed by a host regex. It lets tests exercise the
+// worker pool and checker against realistic proxy-layer failures without
+// touching production dial paths.
+package faultproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"regexp"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Profile describes the fault behavior applied to connections matching a Rule.
+type Profile struct {
+	// AddedLatency is a fixed delay applied before every Read and Write.
+	AddedLatency time.Duration
+
+	// JitterMean and JitterStdDev add normally distributed latency on top of
+	// AddedLatency. Negative samples are clamped to zero.
+	JitterMean   time.Duration
+	JitterStdDev time.Duration
+
+	// DropProbability is the chance, in [0,1], that Dial fails as if the
+	// connection attempt was dropped in flight.
+	DropProbability float64
+
+	// RST makes Dial fail immediately with ErrReset instead of connecting.
+	RST bool
+
+	// BandwidthBps caps sustained throughput on Read and Write via a token
+	// bucket. Zero means unlimited.
+	BandwidthBps int64
+
+	// Blackhole makes Dial block until the context is canceled, simulating
+	// packets vanishing with no RST and no response.
+	Blackhole bool
+}
+
+// Rule binds a Profile to every host:port whose host matches HostPattern.
+type Rule struct {
+	HostPattern string
+	Profile     Profile
+}
+
+// Sentinel errors returned by Dialer for non-network-level fault outcomes.
+var (
+	ErrDropped = errors.New("faultproxy: connection dropped")
+	ErrReset   = errors.New("faultproxy: connection reset by peer")
+)
+
+// Registry holds the live set of fault rules, keyed by host regex. It is
+// safe for concurrent use so the admin endpoint can mutate rules while
+// checks are in flight.
+type Registry struct {
+	mu       sync.RWMutex
+	order    []string
+	rules    map[string]*regexp.Regexp
+	profiles map[string]Profile
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		rules:    make(map[string]*regexp.Regexp),
+		profiles: make(map[string]Profile),
+	}
+}
+
+// Install compiles hostPattern and adds or replaces the rule for it.
+func (r *Registry) Install(hostPattern string, profile Profile) error {
+	re, err := regexp.Compile(hostPattern)
+	if err != nil {
+		return fmt.Errorf("faultproxy: invalid host pattern %q: %w", hostPattern, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.rules[hostPattern]; !exists {
+		r.order = append(r.order, hostPattern)
+	}
+	r.rules[hostPattern] = re
+	r.profiles[hostPattern] = profile
+	return nil
+}
+
+// Remove deletes the rule for hostPattern, reporting whether it existed.
+func (r *Registry) Remove(hostPattern string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rules[hostPattern]; !ok {
+		return false
+	}
+	delete(r.rules, hostPattern)
+	delete(r.profiles, hostPattern)
+	for i, p := range r.order {
+		if p == hostPattern {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// Rules returns a snapshot of the installed rules in install order.
+func (r *Registry) Rules() []Rule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := make([]Rule, 0, len(r.order))
+	for _, pattern := range r.order {
+		rules = append(rules, Rule{HostPattern: pattern, Profile: r.profiles[pattern]})
+	}
+	return rules
+}
+
+// match returns the Profile for the first rule (in install order) whose
+// pattern matches host.
+func (r *Registry) match(host string) (Profile, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, pattern := range r.order {
+		if r.rules[pattern].MatchString(host) {
+			return r.profiles[pattern], true
+		}
+	}
+	return Profile{}, false
+}
+
+// DialContextFunc matches the signature of (*net.Dialer).DialContext, so a
+// Dialer composes with it as the underlying dial function.
+type DialContextFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// Dialer wraps an underlying DialContextFunc with fault injection driven by
+// a Registry. The zero value is not usable; construct with NewDialer.
+type Dialer struct {
+	Registry *Registry
+	Next     DialContextFunc
+}
+
+// NewDialer returns a Dialer backed by registry. If next is nil, a plain
+// *net.Dialer is used as the underlying dial function.
+func NewDialer(registry *Registry, next DialContextFunc) *Dialer {
+	if next == nil {
+		next = (&net.Dialer{}).DialContext
+	}
+	return &Dialer{Registry: registry, Next: next}
+}
+
+// DialContext implements DialContextFunc, applying any fault rule that
+// matches address's host before delegating to the underlying dialer.
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+
+	profile, matched := d.Registry.match(host)
+	if !matched {
+		return d.Next(ctx, network, address)
+	}
+
+	if profile.Blackhole {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if profile.RST {
+		return nil, ErrReset
+	}
+	if profile.DropProbability > 0 && rand.Float64() < profile.DropProbability {
+		return nil, ErrDropped
+	}
+
+	conn, err := d.Next(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFaultConn(conn, profile), nil
+}
+
+// faultConn wraps a net.Conn, applying added latency/jitter and a bandwidth
+// cap to every Read and Write.
+type faultConn struct {
+	net.Conn
+	profile Profile
+	limiter *rate.Limiter
+}
+
+func newFaultConn(conn net.Conn, profile Profile) *faultConn {
+	fc := &faultConn{Conn: conn, profile: profile}
+	if profile.BandwidthBps > 0 {
+		fc.limiter = rate.NewLimiter(rate.Limit(profile.BandwidthBps), int(profile.BandwidthBps))
+	}
+	return fc
+}
+
+func (c *faultConn) delay() {
+	d := c.profile.AddedLatency
+	if c.profile.JitterMean != 0 || c.profile.JitterStdDev > 0 {
+		jitter := time.Duration(rand.NormFloat64()*float64(c.profile.JitterStdDev)) + c.profile.JitterMean
+		if jitter > 0 {
+			d += jitter
+		}
+	}
+	if d > 0 {
+		time.Sleep(d)
+	}
+}
+
+func (c *faultConn) throttle(n int) {
+	if c.limiter == nil || n <= 0 {
+		return
+	}
+	burst := c.limiter.Burst()
+	for n > 0 {
+		take := n
+		if take > burst {
+			take = burst
+		}
+		_ = c.limiter.WaitN(context.Background(), take)
+		n -= take
+	}
+}
+
+func (c *faultConn) Read(b []byte) (int, error) {
+	c.delay()
+	n, err := c.Conn.Read(b)
+	c.throttle(n)
+	return n, err
+}
+
+func (c *faultConn) Write(b []byte) (int, error) {
+	c.delay()
+	n, err := c.Conn.Write(b)
+	c.throttle(n)
+	return n, err
+}
@@ -0,0 +1,187 @@
+// Package geo resolves a proxy server's country code from a MaxMind
+// GeoLite2-Country.mmdb, with a reload path for picking up a refreshed
+// database without restarting a long-running API server.
+package geo
+
+import (
+	"container/list"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/oschwald/geoip2-golang"
+)
+
+// DefaultDBPath matches the filename namira-core has historically expected
+// in its working directory; GEOIP_DB_PATH / --geoip-db override it.
+const DefaultDBPath = "GeoLite2-Country.mmdb"
+
+// resolveCacheSize bounds the host->IP resolution cache so a stream of
+// distinct hostnames can't grow memory unbounded.
+const resolveCacheSize = 4096
+
+// resolveCacheTTL caps how long a resolved IP is trusted before Store
+// resolves the hostname again.
+const resolveCacheTTL = 10 * time.Minute
+
+// Store resolves IPs/hostnames to ISO country codes. It is safe for
+// concurrent use; Reload swaps the underlying database under a write lock so
+// readers never see a half-open reader.
+type Store struct {
+	mu sync.RWMutex
+	db *geoip2.Reader
+
+	cacheMu sync.Mutex
+	cache   map[string]*list.Element
+	order   *list.List
+
+	hits   int64
+	misses int64
+}
+
+// CacheStats summarizes a resolver's lookup cache, for surfacing in
+// health/status endpoints.
+type CacheStats struct {
+	Entries int   `json:"entries"`
+	Hits    int64 `json:"hits"`
+	Misses  int64 `json:"misses"`
+}
+
+type resolveCacheEntry struct {
+	host    string
+	ip      net.IP
+	expires time.Time
+}
+
+// NewStore opens path (a GeoLite2-Country.mmdb) and returns a Store. An
+// empty path uses DefaultDBPath.
+func NewStore(path string) (*Store, error) {
+	if path == "" {
+		path = DefaultDBPath
+	}
+	db, err := geoip2.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open GeoIP database %q: %w", path, err)
+	}
+	return &Store{
+		db:    db,
+		cache: make(map[string]*list.Element),
+		order: list.New(),
+	}, nil
+}
+
+// Reload swaps in a freshly opened database from path, closing the old one
+// once no in-flight lookups can still be using it. Existing resolver cache
+// entries are kept; they describe hostname->IP mappings, not GeoIP data.
+func (s *Store) Reload(path string) error {
+	if path == "" {
+		path = DefaultDBPath
+	}
+	db, err := geoip2.Open(path)
+	if err != nil {
+		return fmt.Errorf("open GeoIP database %q: %w", path, err)
+	}
+
+	s.mu.Lock()
+	old := s.db
+	s.db = db
+	s.mu.Unlock()
+
+	if old != nil {
+		old.Close()
+	}
+	return nil
+}
+
+// Close releases the underlying database file.
+func (s *Store) Close() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+// CountryCode resolves server (an IP literal or hostname) to an ISO country
+// code, or "" if it can't be determined. Hostnames are resolved via the
+// system resolver and cached for resolveCacheTTL.
+func (s *Store) CountryCode(server string) string {
+	if server == "" {
+		return ""
+	}
+
+	ip := net.ParseIP(server)
+	if ip == nil {
+		ip = s.resolveCached(server)
+	}
+	if ip == nil {
+		return ""
+	}
+
+	s.mu.RLock()
+	db := s.db
+	s.mu.RUnlock()
+	if db == nil {
+		return ""
+	}
+
+	record, err := db.Country(ip)
+	if err != nil {
+		return ""
+	}
+	if code := record.Country.IsoCode; code != "" {
+		return code
+	}
+	return record.RegisteredCountry.IsoCode
+}
+
+func (s *Store) resolveCached(host string) net.IP {
+	s.cacheMu.Lock()
+	if el, ok := s.cache[host]; ok {
+		entry := el.Value.(*resolveCacheEntry)
+		if time.Now().Before(entry.expires) {
+			s.order.MoveToFront(el)
+			s.cacheMu.Unlock()
+			atomic.AddInt64(&s.hits, 1)
+			return entry.ip
+		}
+		s.order.Remove(el)
+		delete(s.cache, host)
+	}
+	s.cacheMu.Unlock()
+	atomic.AddInt64(&s.misses, 1)
+
+	ips, err := net.LookupIP(host)
+	if err != nil || len(ips) == 0 {
+		return nil
+	}
+	resolved := ips[0]
+
+	s.cacheMu.Lock()
+	el := s.order.PushFront(&resolveCacheEntry{host: host, ip: resolved, expires: time.Now().Add(resolveCacheTTL)})
+	s.cache[host] = el
+	if s.order.Len() > resolveCacheSize {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.cache, oldest.Value.(*resolveCacheEntry).host)
+	}
+	s.cacheMu.Unlock()
+
+	return resolved
+}
+
+// Stats reports the resolver cache's current size and hit/miss counts.
+func (s *Store) Stats() CacheStats {
+	s.cacheMu.Lock()
+	entries := s.order.Len()
+	s.cacheMu.Unlock()
+
+	return CacheStats{
+		Entries: entries,
+		Hits:    atomic.LoadInt64(&s.hits),
+		Misses:  atomic.LoadInt64(&s.misses),
+	}
+}
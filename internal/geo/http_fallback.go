@@ -0,0 +1,146 @@
+package geo
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// httpCacheSize/httpCacheTTL bound HTTPResolver's hostname->country cache. A
+// longer TTL than Store's DNS cache is fine here: a server's country rarely
+// changes, and each miss costs a rate-limited network round trip instead of
+// a local mmdb lookup.
+const (
+	httpCacheSize = 4096
+	httpCacheTTL  = 30 * time.Minute
+)
+
+type countryResponse struct {
+	IP      string `json:"ip"`
+	Country string `json:"country"`
+}
+
+type httpCacheEntry struct {
+	host    string
+	code    string
+	expires time.Time
+}
+
+// HTTPResolver answers country lookups via api.country.is. It's the
+// fallback GeoIPResolver for deployments with no GeoLite2-Country.mmdb
+// configured, so ReplaceConfigRemark still produces a country flag, just
+// slower and rate-limited. Its cache is keyed by hostname/IP literal so a
+// scan that probes the same server repeatedly only pays for the HTTP call
+// once per httpCacheTTL.
+type HTTPResolver struct {
+	client *http.Client
+
+	cacheMu sync.Mutex
+	cache   map[string]*list.Element
+	order   *list.List
+
+	hits   int64
+	misses int64
+}
+
+// NewHTTPResolver returns an HTTPResolver ready for use.
+func NewHTTPResolver() *HTTPResolver {
+	return &HTTPResolver{
+		client: &http.Client{Timeout: 5 * time.Second},
+		cache:  make(map[string]*list.Element),
+		order:  list.New(),
+	}
+}
+
+// CountryCode resolves server (an IP literal or hostname) to an ISO country
+// code via api.country.is, or "" if the lookup fails.
+func (r *HTTPResolver) CountryCode(server string) string {
+	if server == "" {
+		return ""
+	}
+
+	if code, ok := r.cached(server); ok {
+		return code
+	}
+
+	ip := server
+	if parsed := net.ParseIP(server); parsed == nil {
+		ips, err := net.LookupIP(server)
+		if err != nil || len(ips) == 0 {
+			return ""
+		}
+		ip = ips[0].String()
+	}
+
+	resp, err := r.client.Get(fmt.Sprintf("https://api.country.is/%s", ip))
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+
+	var countryResp countryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&countryResp); err != nil {
+		return ""
+	}
+
+	r.store(server, countryResp.Country)
+	return countryResp.Country
+}
+
+func (r *HTTPResolver) cached(host string) (string, bool) {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	el, ok := r.cache[host]
+	if !ok {
+		atomic.AddInt64(&r.misses, 1)
+		return "", false
+	}
+
+	entry := el.Value.(*httpCacheEntry)
+	if time.Now().After(entry.expires) {
+		r.order.Remove(el)
+		delete(r.cache, host)
+		atomic.AddInt64(&r.misses, 1)
+		return "", false
+	}
+
+	r.order.MoveToFront(el)
+	atomic.AddInt64(&r.hits, 1)
+	return entry.code, true
+}
+
+func (r *HTTPResolver) store(host, code string) {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	el := r.order.PushFront(&httpCacheEntry{host: host, code: code, expires: time.Now().Add(httpCacheTTL)})
+	r.cache[host] = el
+	if r.order.Len() > httpCacheSize {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.cache, oldest.Value.(*httpCacheEntry).host)
+	}
+}
+
+// Stats reports the resolver cache's current size and hit/miss counts.
+func (r *HTTPResolver) Stats() CacheStats {
+	r.cacheMu.Lock()
+	entries := r.order.Len()
+	r.cacheMu.Unlock()
+
+	return CacheStats{
+		Entries: entries,
+		Hits:    atomic.LoadInt64(&r.hits),
+		Misses:  atomic.LoadInt64(&r.misses),
+	}
+}
@@ -0,0 +1,237 @@
+package geo
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// maxmindDownloadURL is MaxMind's permalink download endpoint; edition_id
+// and license_key select the database, suffix selects the archive format.
+const maxmindDownloadURL = "https://download.maxmind.com/app/geoip_download"
+
+// ManagerConfig configures automatic download and periodic refresh of a
+// GeoLite2 database that a GeoIPManager keeps a Store pointed at.
+type ManagerConfig struct {
+	DBPath     string // local file NewStore/Reload are pointed at
+	AccountID  string // MaxMind account ID; unused by the license-key download flow but kept for callers that need it
+	LicenseKey string
+	EditionID  string // e.g. "GeoLite2-Country"; defaults to that
+	TTL        time.Duration
+	Client     *http.Client
+}
+
+func (c ManagerConfig) orDefaults() ManagerConfig {
+	if c.EditionID == "" {
+		c.EditionID = "GeoLite2-Country"
+	}
+	if c.TTL <= 0 {
+		c.TTL = 7 * 24 * time.Hour
+	}
+	if c.Client == nil {
+		c.Client = &http.Client{Timeout: 60 * time.Second}
+	}
+	return c
+}
+
+// Status reports a GeoIPManager's active database build date and last
+// refresh time, for surfacing in HealthResponse.
+type Status struct {
+	BuildDate   time.Time `json:"build_date,omitempty"`
+	LastRefresh time.Time `json:"last_refresh,omitempty"`
+}
+
+// GeoIPManager keeps a *Store pointed at an up-to-date GeoLite2 database:
+// downloading it on startup if missing or older than TTL, verifying the
+// accompanying SHA256 sidecar, and reloading on a ticker so CountryCode
+// lookups (which read the Store's reader under its own RWMutex) never see
+// a closed handle mid-rotation.
+type GeoIPManager struct {
+	cfg   ManagerConfig
+	store *Store
+
+	lastRefresh atomic.Pointer[time.Time]
+	buildDate   atomic.Pointer[time.Time]
+}
+
+func NewGeoIPManager(cfg ManagerConfig) *GeoIPManager {
+	return &GeoIPManager{cfg: cfg.orDefaults()}
+}
+
+// Start opens (downloading first if the local file is missing or older
+// than cfg.TTL) the database, then spawns a background goroutine that
+// re-checks every cfg.TTL until ctx is done.
+func (m *GeoIPManager) Start(ctx context.Context) (*Store, error) {
+	if m.needsDownload() {
+		if err := m.download(ctx); err != nil {
+			return nil, fmt.Errorf("download initial GeoIP database: %w", err)
+		}
+	}
+
+	store, err := NewStore(m.cfg.DBPath)
+	if err != nil {
+		return nil, err
+	}
+	m.store = store
+	m.recordRefresh()
+
+	go m.refreshLoop(ctx)
+	return store, nil
+}
+
+func (m *GeoIPManager) needsDownload() bool {
+	info, err := os.Stat(m.cfg.DBPath)
+	if err != nil {
+		return true
+	}
+	return time.Since(info.ModTime()) > m.cfg.TTL
+}
+
+func (m *GeoIPManager) refreshLoop(ctx context.Context) {
+	ticker := time.NewTicker(m.cfg.TTL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = m.Refresh(ctx)
+		}
+	}
+}
+
+// Refresh downloads the latest database and hot-swaps it into the Store
+// regardless of TTL — the backing for an on-demand admin endpoint. On
+// failure the Store keeps serving its last good database.
+func (m *GeoIPManager) Refresh(ctx context.Context) error {
+	if err := m.download(ctx); err != nil {
+		return err
+	}
+	if m.store == nil {
+		return fmt.Errorf("geoip manager: not started")
+	}
+	if err := m.store.Reload(m.cfg.DBPath); err != nil {
+		return err
+	}
+	m.recordRefresh()
+	return nil
+}
+
+func (m *GeoIPManager) recordRefresh() {
+	now := time.Now()
+	m.lastRefresh.Store(&now)
+	if info, err := os.Stat(m.cfg.DBPath); err == nil {
+		mtime := info.ModTime()
+		m.buildDate.Store(&mtime)
+	}
+}
+
+// Status reports the active database's build date and last refresh time.
+func (m *GeoIPManager) Status() Status {
+	var s Status
+	if t := m.buildDate.Load(); t != nil {
+		s.BuildDate = *t
+	}
+	if t := m.lastRefresh.Load(); t != nil {
+		s.LastRefresh = *t
+	}
+	return s
+}
+
+// download fetches cfg.EditionID's tar.gz from MaxMind, verifies it
+// against the accompanying .sha256 sidecar when available, and extracts
+// the .mmdb it contains into cfg.DBPath.
+func (m *GeoIPManager) download(ctx context.Context) error {
+	if m.cfg.LicenseKey == "" {
+		return fmt.Errorf("geoip manager: no MaxMind license key configured")
+	}
+
+	archiveData, err := m.fetch(ctx, "tar.gz")
+	if err != nil {
+		return fmt.Errorf("geoip manager: download archive: %w", err)
+	}
+
+	if sidecar, err := m.fetch(ctx, "tar.gz.sha256"); err == nil {
+		fields := strings.Fields(string(sidecar))
+		if len(fields) > 0 {
+			sum := sha256.Sum256(archiveData)
+			if hex.EncodeToString(sum[:]) != fields[0] {
+				return fmt.Errorf("geoip manager: SHA256 mismatch for %s", m.cfg.EditionID)
+			}
+		}
+	}
+
+	return extractMMDB(archiveData, m.cfg.DBPath)
+}
+
+func (m *GeoIPManager) fetch(ctx context.Context, suffix string) ([]byte, error) {
+	url := fmt.Sprintf("%s?edition_id=%s&license_key=%s&suffix=%s", maxmindDownloadURL, m.cfg.EditionID, m.cfg.LicenseKey, suffix)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.cfg.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download returned status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// extractMMDB writes the first *.mmdb entry found in a MaxMind tar.gz
+// archive to destPath, via a temp file + rename so a reader mid-Reload
+// never sees a partially written database.
+func extractMMDB(archiveData []byte, destPath string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(archiveData))
+	if err != nil {
+		return fmt.Errorf("open gzip: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return fmt.Errorf("no .mmdb file found in archive")
+		}
+		if err != nil {
+			return fmt.Errorf("read tar: %w", err)
+		}
+		if !strings.HasSuffix(header.Name, ".mmdb") {
+			continue
+		}
+
+		tmpPath := destPath + ".tmp"
+		out, err := os.Create(tmpPath)
+		if err != nil {
+			return fmt.Errorf("create temp file: %w", err)
+		}
+		if _, err := io.Copy(out, tr); err != nil {
+			out.Close()
+			os.Remove(tmpPath)
+			return fmt.Errorf("write temp file: %w", err)
+		}
+		out.Close()
+
+		if err := os.Rename(tmpPath, destPath); err != nil {
+			os.Remove(tmpPath)
+			return fmt.Errorf("rename into place: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,90 @@
+package github
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// compressionCodec is a single framing byte prepended to the compressed
+// body so decompress can detect it. Values 1 and 2 can't collide with a
+// pre-compression results.txt (those decrypt straight to JSON starting with
+// '{' = 0x7b), so legacy files without this framing still decrypt.
+type compressionCodec byte
+
+const (
+	codecNone compressionCodec = 0
+	codecGzip compressionCodec = 1
+	codecZstd compressionCodec = 2
+)
+
+// compress codec-compresses data and prepends the framing byte identifying
+// codec ("zstd", "gzip", or "none"/"").
+func compress(data []byte, codec string) ([]byte, error) {
+	switch codec {
+	case "", "none":
+		return append([]byte{byte(codecNone)}, data...), nil
+	case "gzip":
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("gzip compress: %w", err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("gzip compress: %w", err)
+		}
+		return append([]byte{byte(codecGzip)}, buf.Bytes()...), nil
+	case "zstd":
+		w, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, fmt.Errorf("zstd compress: %w", err)
+		}
+		defer w.Close()
+		return append([]byte{byte(codecZstd)}, w.EncodeAll(data, nil)...), nil
+	default:
+		return nil, fmt.Errorf("unknown compression codec: %s", codec)
+	}
+}
+
+// decompress reads the framing byte written by compress and inflates
+// accordingly. Content encrypted before compress/decompress existed has no
+// framing byte - it decrypts straight to JSON, whose first byte ('{' =
+// 0x7b) never matches a codecNone/codecGzip/codecZstd value, so it's
+// returned unchanged.
+func decompress(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	switch compressionCodec(data[0]) {
+	case codecNone:
+		return data[1:], nil
+	case codecGzip:
+		r, err := gzip.NewReader(bytes.NewReader(data[1:]))
+		if err != nil {
+			return nil, fmt.Errorf("gzip decompress: %w", err)
+		}
+		defer r.Close()
+		out, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("gzip decompress: %w", err)
+		}
+		return out, nil
+	case codecZstd:
+		r, err := zstd.NewReader(bytes.NewReader(data[1:]))
+		if err != nil {
+			return nil, fmt.Errorf("zstd decompress: %w", err)
+		}
+		defer r.Close()
+		out, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("zstd decompress: %w", err)
+		}
+		return out, nil
+	default:
+		return data, nil
+	}
+}
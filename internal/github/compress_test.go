@@ -0,0 +1,104 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCompressDecompress_RoundTrip(t *testing.T) {
+	data, err := realisticResultsJSON(500)
+	if err != nil {
+		t.Fatalf("realisticResultsJSON: %v", err)
+	}
+
+	for _, codec := range []string{"", "none", "gzip", "zstd"} {
+		t.Run(codec, func(t *testing.T) {
+			compressed, err := compress(data, codec)
+			if err != nil {
+				t.Fatalf("compress: %v", err)
+			}
+			decompressed, err := decompress(compressed)
+			if err != nil {
+				t.Fatalf("decompress: %v", err)
+			}
+			if string(decompressed) != string(data) {
+				t.Fatalf("decompressed length = %d, want %d", len(decompressed), len(data))
+			}
+		})
+	}
+}
+
+func TestDecompress_UnframedLegacyData(t *testing.T) {
+	// Content encrypted before compress/decompress existed has no framing
+	// byte and decrypts straight to JSON, whose leading '{' (0x7b) doesn't
+	// match any known codec byte.
+	legacy := []byte(`{"job_id":"legacy"}`)
+	out, err := decompress(legacy)
+	if err != nil {
+		t.Fatalf("decompress: %v", err)
+	}
+	if string(out) != string(legacy) {
+		t.Fatalf("decompress altered unframed legacy data: got %q, want %q", out, legacy)
+	}
+}
+
+func TestCompress_UnknownCodec(t *testing.T) {
+	if _, err := compress([]byte("data"), "lz4"); err == nil {
+		t.Fatalf("expected an error for an unknown codec")
+	}
+}
+
+// realisticResultsJSON builds a marshaled JSONResult with n config entries,
+// representative of what writeEncryptedContent compresses in production:
+// repetitive field values (protocol, status) but unique remarks/configs, so
+// compression ratio reflects real-world redundancy rather than either an
+// all-zeros or fully-random payload.
+func realisticResultsJSON(n int) ([]byte, error) {
+	results := make([]JSONConfigResult, n)
+	protocols := []string{"vless", "vmess", "trojan", "ss", "hysteria2"}
+	for i := range results {
+		results[i] = JSONConfigResult{
+			Delay:       int64(50 + i%400),
+			Status:      "success",
+			Protocol:    protocols[i%len(protocols)],
+			RawConfig:   fmt.Sprintf("%s://uuid-%d@server-%d.example.com:443?security=tls#Config-%d", protocols[i%len(protocols)], i, i%50, i),
+			CountryCode: []string{"US", "DE", "NL", "SG", "JP"}[i%5],
+			Remark:      fmt.Sprintf("✨ NaMiraNet | 🌐 server-%d.example.com | 🇺🇸", i%50),
+			Server:      fmt.Sprintf("server-%d.example.com", i%50),
+		}
+	}
+	return json.Marshal(JSONResult{
+		JobID:     "bench-job",
+		Timestamp: time.Unix(0, 0),
+		Results:   results,
+	})
+}
+
+// BenchmarkCompress reports the size reduction each codec achieves on a
+// realistic 2000-config result set, so a regression in compression ratio
+// (e.g. an accidental codec downgrade) shows up in benchmark output instead
+// of only at GitHub fetch time.
+func BenchmarkCompress(b *testing.B) {
+	data, err := realisticResultsJSON(2000)
+	if err != nil {
+		b.Fatalf("realisticResultsJSON: %v", err)
+	}
+
+	for _, codec := range []string{"none", "gzip", "zstd"} {
+		b.Run(codec, func(b *testing.B) {
+			var compressed []byte
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				var err error
+				compressed, err = compress(data, codec)
+				if err != nil {
+					b.Fatalf("compress: %v", err)
+				}
+			}
+			b.ReportMetric(float64(len(data))/float64(len(compressed)), "ratio")
+		})
+	}
+}
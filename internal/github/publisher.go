@@ -0,0 +1,210 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	scanResultsChannel   = "scan_results_channel"
+	scanResultsStream    = "scan_results_stream"
+	scanResultsWatermark = "scan_results_stream:committed_id"
+)
+
+// pendingDelta is one job's contribution to the next coalesced flush.
+type pendingDelta struct {
+	JobID   string
+	Results []JSONConfigResult
+}
+
+// publisher buffers incremental scan results in memory (backed by an
+// append-only Redis stream for crash recovery) and periodically coalesces
+// them into a single store.Put, instead of every scan job triggering its
+// own clone/merge/push. See Updater.Start.
+type publisher struct {
+	u             *Updater
+	flushInterval time.Duration
+
+	mu      sync.Mutex
+	pending map[string]pendingDelta // keyed by hashConfig(RawConfig)
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+func newPublisher(u *Updater, flushInterval time.Duration) *publisher {
+	return &publisher{
+		u:             u,
+		flushInterval: flushInterval,
+		pending:       make(map[string]pendingDelta),
+	}
+}
+
+// enqueue appends jobID's results to the stream for durability, buffers them
+// in memory (deduped against whatever is already pending), and notifies
+// scan_results_channel subscribers. It returns once the stream append
+// succeeds - the coalesced commit itself happens on the next flush.
+func (p *publisher) enqueue(ctx context.Context, jobID string, results JSONResult) error {
+	payload, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("marshal delta: %w", err)
+	}
+
+	streamID, err := p.u.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: scanResultsStream,
+		Values: map[string]interface{}{"job_id": jobID, "results": payload},
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("append to results stream: %w", err)
+	}
+
+	p.buffer(jobID, results.Results)
+
+	if err := p.u.redisClient.Publish(ctx, scanResultsChannel, streamID).Err(); err != nil {
+		p.u.logger.Warn("Failed to publish scan results notification", zap.Error(err))
+	}
+
+	return nil
+}
+
+func (p *publisher) buffer(jobID string, results []JSONConfigResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, result := range results {
+		p.pending[p.u.hashConfig(result.RawConfig)] = pendingDelta{JobID: jobID, Results: []JSONConfigResult{result}}
+	}
+}
+
+// replay loads deltas appended after the last committed flush (read from
+// scanResultsWatermark) back into the in-memory buffer, so a crash between
+// an enqueue and the next flush doesn't lose results.
+func (p *publisher) replay(ctx context.Context) error {
+	watermark, err := p.u.redisClient.Get(ctx, scanResultsWatermark).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("read flush watermark: %w", err)
+	}
+
+	start := "-"
+	if watermark != "" {
+		start = "(" + watermark
+	}
+
+	entries, err := p.u.redisClient.XRange(ctx, scanResultsStream, start, "+").Result()
+	if err != nil {
+		return fmt.Errorf("read results stream: %w", err)
+	}
+
+	for _, entry := range entries {
+		jobID, _ := entry.Values["job_id"].(string)
+		payload, _ := entry.Values["results"].(string)
+
+		var results JSONResult
+		if err := json.Unmarshal([]byte(payload), &results); err != nil {
+			p.u.logger.Warn("Skipping unreadable stream entry during replay",
+				zap.String("stream_id", entry.ID), zap.Error(err))
+			continue
+		}
+
+		p.buffer(jobID, results.Results)
+	}
+
+	if len(entries) > 0 {
+		p.u.logger.Info("Replayed pending scan results from stream", zap.Int("entries", len(entries)))
+	}
+
+	return nil
+}
+
+// run coalesces buffered deltas into one commit every flushInterval, and
+// once more on Stop, until ctx is canceled or stopCh is closed.
+func (p *publisher) run(ctx context.Context) {
+	defer close(p.doneCh)
+
+	ticker := time.NewTicker(p.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.flush(ctx); err != nil {
+				p.u.logger.Error("Failed to flush scan results", zap.Error(err))
+			}
+		case <-p.stopCh:
+			if err := p.flush(ctx); err != nil {
+				p.u.logger.Error("Failed to flush scan results on shutdown", zap.Error(err))
+			}
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *publisher) flush(ctx context.Context) error {
+	p.mu.Lock()
+	if len(p.pending) == 0 {
+		p.mu.Unlock()
+		return nil
+	}
+
+	jobIDs := make(map[string]struct{}, len(p.pending))
+	results := make([]JSONConfigResult, 0, len(p.pending))
+	for _, delta := range p.pending {
+		jobIDs[delta.JobID] = struct{}{}
+		results = append(results, delta.Results...)
+	}
+	p.mu.Unlock()
+
+	jobIDList := make([]string, 0, len(jobIDs))
+	for jobID := range jobIDs {
+		jobIDList = append(jobIDList, jobID)
+	}
+
+	lastID, err := p.latestStreamID(ctx)
+	if err != nil {
+		return fmt.Errorf("read latest stream id: %w", err)
+	}
+
+	current := JSONResult{
+		JobID:     fmt.Sprintf("coalesced-%d", len(jobIDList)),
+		Timestamp: time.Now(),
+		Results:   results,
+	}
+
+	if err := p.u.persist(current.JobID, current, true); err != nil {
+		return fmt.Errorf("persist coalesced results: %w", err)
+	}
+
+	if lastID != "" {
+		if err := p.u.redisClient.Set(ctx, scanResultsWatermark, lastID, 0).Err(); err != nil {
+			p.u.logger.Warn("Failed to record flush watermark", zap.Error(err))
+		}
+	}
+
+	p.mu.Lock()
+	clear(p.pending)
+	p.mu.Unlock()
+
+	p.u.logger.Info("Flushed coalesced scan results",
+		zap.Int("jobs", len(jobIDList)), zap.Int("configs", len(results)))
+	return nil
+}
+
+func (p *publisher) latestStreamID(ctx context.Context) (string, error) {
+	entries, err := p.u.redisClient.XRevRangeN(ctx, scanResultsStream, "+", "-", 1).Result()
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 0 {
+		return "", nil
+	}
+	return entries[0].ID, nil
+}
@@ -0,0 +1,55 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NaMiraNet/namira-core/internal/config"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// ResultStore is the destination Updater persists the encrypted results.txt
+// blob to. Implementations round-trip the same AES-GCM envelope
+// (crypto.Encrypt/crypto.Decrypt, base64-encoded) that writeEncryptedContent
+// has always produced; Updater never sees backend-specific bytes.
+type ResultStore interface {
+	// Get fetches the current blob. A (nil, nil) return means nothing has
+	// been stored yet, which callers treat as an empty merge base rather
+	// than an error.
+	Get(ctx context.Context) ([]byte, error)
+
+	// Put stores data, tagged with jobID for backends that version or log
+	// by job (Git commit messages, S3 object metadata).
+	Put(ctx context.Context, jobID string, data []byte) error
+
+	// List returns identifiers of the objects/jobs currently stored, for
+	// operators inspecting backend state without decrypting anything.
+	List(ctx context.Context) ([]string, error)
+}
+
+// HealthChecker is implemented by ResultStore backends that can verify
+// connectivity up front (gitResultStore's SSH clone test, s3ResultStore's
+// bucket check). Backends with nothing meaningful to check don't implement it.
+type HealthChecker interface {
+	HealthCheck() error
+}
+
+// NewResultStore builds the ResultStore selected by cfg.StorageBackend
+// ("git" is the default, matching the original SSH + raw.githubusercontent
+// behavior). redisClient is reused for the "redis" backend so callers don't
+// need a second connection.
+func NewResultStore(log *zap.Logger, cfg config.GithubConfig, redisClient redis.UniversalClient) (ResultStore, error) {
+	switch cfg.StorageBackend {
+	case "", "git":
+		return newGitResultStore(log, cfg.SSHKeyPath, cfg.Owner, cfg.Repo)
+	case "s3":
+		return newS3ResultStore(cfg.S3Endpoint, cfg.S3Bucket, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3Region, cfg.S3UseSSL)
+	case "fs":
+		return newFSResultStore(cfg.FSPath), nil
+	case "redis":
+		return newRedisResultStore(redisClient, cfg.RedisKey), nil
+	default:
+		return nil, fmt.Errorf("unknown github storage backend: %s", cfg.StorageBackend)
+	}
+}
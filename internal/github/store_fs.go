@@ -0,0 +1,46 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// fsResultStore stores the encrypted blob at a single path on local disk.
+// It's meant for operators running namira-core standalone, without a
+// distribution channel beyond the machine it runs on.
+type fsResultStore struct {
+	path string
+}
+
+func newFSResultStore(path string) *fsResultStore {
+	return &fsResultStore{path: path}
+}
+
+func (s *fsResultStore) Get(ctx context.Context) ([]byte, error) {
+	content, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", s.path, err)
+	}
+	return content, nil
+}
+
+func (s *fsResultStore) Put(ctx context.Context, jobID string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", s.path, err)
+	}
+	return os.WriteFile(s.path, data, FILE_PERMS)
+}
+
+func (s *fsResultStore) List(ctx context.Context) ([]string, error) {
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", s.path, err)
+	}
+	return []string{filepath.Base(s.path)}, nil
+}
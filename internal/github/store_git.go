@@ -0,0 +1,179 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"go.uber.org/zap"
+)
+
+// gitResultStore is the original ResultStore backend: FILENAME lives at the
+// repo root of a GitHub repo, read over the unauthenticated
+// raw.githubusercontent.com mirror when possible and written by cloning over
+// SSH, committing, and pushing.
+type gitResultStore struct {
+	auth      *ssh.PublicKeys
+	repoOwner string
+	repoName  string
+	repoURL   string
+	workDir   string
+	logger    *zap.Logger
+}
+
+func newGitResultStore(log *zap.Logger, sshKeyPath, repoOwner, repoName string) (*gitResultStore, error) {
+	if _, err := os.Stat(sshKeyPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("SSH key file not found: %s", sshKeyPath)
+	}
+
+	auth, err := ssh.NewPublicKeysFromFile("git", sshKeyPath, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load SSH key: %w", err)
+	}
+
+	return &gitResultStore{
+		auth:      auth,
+		repoOwner: repoOwner,
+		repoName:  repoName,
+		repoURL:   fmt.Sprintf("git@github.com:%s/%s.git", repoOwner, repoName),
+		workDir:   fmt.Sprintf("/tmp/namira-core-updater-%s-%s", repoOwner, repoName),
+		logger:    log,
+	}, nil
+}
+
+// HealthCheck tests SSH connectivity to GitHub.
+func (s *gitResultStore) HealthCheck() error {
+	tempDir := s.workDir + "-healthcheck"
+	defer os.RemoveAll(tempDir)
+
+	_, err := git.PlainClone(tempDir, false, &git.CloneOptions{
+		URL:   s.repoURL,
+		Auth:  s.auth,
+		Depth: CLONE_DEPTH,
+	})
+	return err
+}
+
+// Get prefers the unauthenticated raw.githubusercontent.com mirror, falling
+// back to an SSH clone when that's unavailable (private repo, GitHub outage,
+// rate limiting, etc.).
+func (s *gitResultStore) Get(ctx context.Context) ([]byte, error) {
+	content, err := s.getViaHTTP(ctx)
+	if err == nil {
+		return content, nil
+	}
+
+	s.logger.Warn("HTTP fetch failed, falling back to Git clone", zap.Error(err))
+	return s.getViaGit()
+}
+
+func (s *gitResultStore) getViaHTTP(ctx context.Context) ([]byte, error) {
+	rawURL := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/main/%s", s.repoOwner, s.repoName, FILENAME)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("User-Agent", "namira-core/1.1.0")
+	req.Header.Set("Accept", "text/plain")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP error: %d %s", resp.StatusCode, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func (s *gitResultStore) getViaGit() ([]byte, error) {
+	os.RemoveAll(s.workDir)
+	defer os.RemoveAll(s.workDir)
+
+	_, err := git.PlainClone(s.workDir, false, &git.CloneOptions{
+		URL:   s.repoURL,
+		Auth:  s.auth,
+		Depth: CLONE_DEPTH,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone repository: %w", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(s.workDir, FILENAME))
+	if err != nil {
+		return nil, nil
+	}
+	return content, nil
+}
+
+func (s *gitResultStore) Put(ctx context.Context, jobID string, data []byte) error {
+	os.RemoveAll(s.workDir)
+	defer os.RemoveAll(s.workDir)
+
+	repo, err := git.PlainClone(s.workDir, false, &git.CloneOptions{
+		URL:   s.repoURL,
+		Auth:  s.auth,
+		Depth: CLONE_DEPTH,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clone repository: %w", err)
+	}
+
+	filePath := filepath.Join(s.workDir, FILENAME)
+	if err := os.WriteFile(filePath, data, FILE_PERMS); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	if _, err := worktree.Add(FILENAME); err != nil {
+		return fmt.Errorf("failed to add file: %w", err)
+	}
+
+	_, err = worktree.Commit(fmt.Sprintf("🤖 Update scan results - Job %s", jobID), &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  BOT_NAME,
+			Email: BOT_EMAIL,
+			When:  time.Now(),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	if err := repo.Push(&git.PushOptions{
+		RemoteName: REMOTE_NAME,
+		Auth:       s.auth,
+	}); err != nil {
+		return fmt.Errorf("failed to push: %w", err)
+	}
+	return nil
+}
+
+// List reports FILENAME if it currently exists in the repo, matching the
+// single-file layout this backend has always used.
+func (s *gitResultStore) List(ctx context.Context) ([]string, error) {
+	content, err := s.getViaHTTP(ctx)
+	if err != nil || content == nil {
+		return nil, nil
+	}
+	return []string{FILENAME}, nil
+}
@@ -0,0 +1,50 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisResultStore stores the encrypted blob under a single key, for
+// operators who already run Redis for job state and don't want to stand up
+// a separate object store.
+type redisResultStore struct {
+	client redis.UniversalClient
+	key    string
+}
+
+func newRedisResultStore(client redis.UniversalClient, key string) *redisResultStore {
+	return &redisResultStore{client: client, key: key}
+}
+
+func (s *redisResultStore) Get(ctx context.Context) ([]byte, error) {
+	data, err := s.client.Get(ctx, s.key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", s.key, err)
+	}
+	return data, nil
+}
+
+func (s *redisResultStore) Put(ctx context.Context, jobID string, data []byte) error {
+	if err := s.client.Set(ctx, s.key, data, 0).Err(); err != nil {
+		return fmt.Errorf("set %s: %w", s.key, err)
+	}
+	return nil
+}
+
+func (s *redisResultStore) List(ctx context.Context) ([]string, error) {
+	exists, err := s.client.Exists(ctx, s.key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("exists %s: %w", s.key, err)
+	}
+	if exists == 0 {
+		return nil, nil
+	}
+	return []string{s.key}, nil
+}
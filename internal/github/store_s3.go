@@ -0,0 +1,86 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// s3ResultStore stores the encrypted blob as a single object in an
+// S3-compatible bucket (AWS S3, MinIO, R2, etc.), for operators who already
+// have object storage and don't want GitHub as a distribution channel.
+type s3ResultStore struct {
+	client *minio.Client
+	bucket string
+	key    string
+}
+
+func newS3ResultStore(endpoint, bucket, accessKey, secretKey, region string, useSSL bool) (*s3ResultStore, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+		Region: region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create S3 client: %w", err)
+	}
+
+	return &s3ResultStore{client: client, bucket: bucket, key: FILENAME}, nil
+}
+
+// HealthCheck verifies the bucket is reachable with the configured credentials.
+func (s *s3ResultStore) HealthCheck() error {
+	exists, err := s.client.BucketExists(context.Background(), s.bucket)
+	if err != nil {
+		return fmt.Errorf("check bucket %s: %w", s.bucket, err)
+	}
+	if !exists {
+		return fmt.Errorf("bucket %s does not exist", s.bucket)
+	}
+	return nil
+}
+
+func (s *s3ResultStore) Get(ctx context.Context) ([]byte, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, s.key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("get object %s: %w", s.key, err)
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(obj)
+	var resp minio.ErrorResponse
+	if errors.As(err, &resp) && resp.Code == "NoSuchKey" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read object %s: %w", s.key, err)
+	}
+	return data, nil
+}
+
+func (s *s3ResultStore) Put(ctx context.Context, jobID string, data []byte) error {
+	_, err := s.client.PutObject(ctx, s.bucket, s.key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType:  "text/plain",
+		UserMetadata: map[string]string{"job-id": jobID},
+	})
+	if err != nil {
+		return fmt.Errorf("put object %s: %w", s.key, err)
+	}
+	return nil
+}
+
+func (s *s3ResultStore) List(ctx context.Context) ([]string, error) {
+	var keys []string
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: s.key}) {
+		if obj.Err != nil {
+			return nil, obj.Err
+		}
+		keys = append(keys, obj.Key)
+	}
+	return keys, nil
+}
@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NaMiraNet/namira-core/internal/crypto"
+	"github.com/redis/go-redis/v9"
+)
+
+// envelopeRoundTrip asserts store round-trips the same AES-GCM envelope
+// writeEncryptedContent/decryptAndParseConfigs produce: a plaintext blob
+// gets encrypted, stored, fetched back, and decrypted to the same bytes.
+func envelopeRoundTrip(t *testing.T, store ResultStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	plaintext := []byte(`{"configs":["vless://example"]}`)
+
+	before, err := store.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get before Put: %v", err)
+	}
+	if before != nil {
+		t.Fatalf("Get before Put = %v, want nil (nothing stored yet)", before)
+	}
+
+	encrypted, err := crypto.Encrypt(plaintext, key)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	if err := store.Put(ctx, "job-1", encrypted); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	fetched, err := store.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get after Put: %v", err)
+	}
+
+	decrypted, err := crypto.Decrypt(fetched, key)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if string(decrypted) != string(plaintext) {
+		t.Fatalf("decrypted = %q, want %q", decrypted, plaintext)
+	}
+
+	ids, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(ids) == 0 {
+		t.Fatalf("List returned no identifiers after Put")
+	}
+}
+
+func TestFSResultStore_RoundTrip(t *testing.T) {
+	store := newFSResultStore(filepath.Join(t.TempDir(), "results.txt"))
+	envelopeRoundTrip(t, store)
+}
+
+func TestRedisResultStore_RoundTrip(t *testing.T) {
+	addr := "127.0.0.1:6379"
+	conn, err := net.DialTimeout("tcp", addr, 200*time.Millisecond)
+	if err != nil {
+		t.Skipf("no local redis at %s to test against: %v", addr, err)
+	}
+	conn.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	defer client.Close()
+
+	key := "namira-core:test:results:" + t.Name()
+	defer client.Del(context.Background(), key)
+
+	store := newRedisResultStore(client, key)
+	envelopeRoundTrip(t, store)
+}
@@ -7,18 +7,11 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"os"
-	"path/filepath"
 	"sort"
 	"time"
 
-	"github.com/NamiraNet/namira-core/internal/core"
-	"github.com/NamiraNet/namira-core/internal/crypto"
-	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/plumbing/object"
-	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/NaMiraNet/namira-core/internal/core"
+	"github.com/NaMiraNet/namira-core/internal/crypto"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
@@ -33,14 +26,14 @@ const (
 )
 
 type Updater struct {
-	auth          *ssh.PublicKeys
-	redisClient   *redis.Client
-	repoOwner     string
-	repoName      string
-	repoURL       string
-	encryptionKey []byte
-	logger        *zap.Logger
-	workDir       string
+	store        ResultStore
+	redisClient  redis.UniversalClient
+	keyring      *crypto.Keyring
+	envelopeMode bool
+	compression  string
+	logger       *zap.Logger
+
+	publisher *publisher
 }
 
 type ScanResult struct {
@@ -65,40 +58,62 @@ type JSONConfigResult struct {
 	Server      string `json:"server"`
 }
 
-func NewUpdater(log *zap.Logger, sshKeyPath string, redisClient *redis.Client, repoOwner, repoName string, encryptionKey []byte) (*Updater, error) {
-	if _, err := os.Stat(sshKeyPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("SSH key file not found: %s", sshKeyPath)
-	}
-
-	// Setup SSH authentication
-	auth, err := ssh.NewPublicKeysFromFile("git", sshKeyPath, "")
-	if err != nil {
-		return nil, fmt.Errorf("failed to load SSH key: %w", err)
-	}
-
-	return &Updater{
-		auth:          auth,
-		redisClient:   redisClient,
-		repoOwner:     repoOwner,
-		repoName:      repoName,
-		repoURL:       fmt.Sprintf("git@github.com:%s/%s.git", repoOwner, repoName),
-		encryptionKey: encryptionKey,
-		logger:        log,
-		workDir:       fmt.Sprintf("/tmp/namira-core-updater-%s-%s", repoOwner, repoName),
-	}, nil
+// NewUpdater builds an Updater that persists scan results through store
+// (see NewResultStore for picking a backend via config.GithubConfig).
+// redisClient is where scan/refresh job results are read from - independent
+// of whichever backend store itself uses. keyring seals/opens the stored
+// content (see crypto.Keyring); when envelopeMode is true, content is sealed
+// under a fresh per-write data key wrapped by the keyring's active key
+// (crypto.Keyring.EncryptDEK) instead of directly under that key. compression
+// is the codec ("zstd", "gzip", or "none"/"") content is compressed with
+// before encryption. flushInterval controls how often ProcessScanResults'
+// buffered deltas are coalesced into one store.Put (see Start).
+func NewUpdater(log *zap.Logger, store ResultStore, redisClient redis.UniversalClient, keyring *crypto.Keyring, envelopeMode bool, compression string, flushInterval time.Duration) *Updater {
+	u := &Updater{
+		store:        store,
+		redisClient:  redisClient,
+		keyring:      keyring,
+		envelopeMode: envelopeMode,
+		compression:  compression,
+		logger:       log,
+	}
+	u.publisher = newPublisher(u, flushInterval)
+	return u
 }
 
-// HealthCheck tests SSH connectivity to GitHub
+// HealthCheck verifies connectivity to the configured store, when the store
+// backend supports checking it up front.
 func (u *Updater) HealthCheck() error {
-	tempDir := u.workDir + "-healthcheck"
-	defer os.RemoveAll(tempDir)
+	if hc, ok := u.store.(HealthChecker); ok {
+		return hc.HealthCheck()
+	}
+	return nil
+}
 
-	_, err := git.PlainClone(tempDir, false, &git.CloneOptions{
-		URL:   u.repoURL,
-		Auth:  u.auth,
-		Depth: CLONE_DEPTH,
-	})
-	return err
+// Start replays any scan results buffered before a crash (see
+// publisher.replay) and begins coalescing ProcessScanResults deltas into
+// periodic commits. Call Stop to flush the remaining buffer and shut the
+// background loop down.
+func (u *Updater) Start(ctx context.Context) error {
+	if err := u.publisher.replay(ctx); err != nil {
+		return fmt.Errorf("replay scan results stream: %w", err)
+	}
+
+	u.publisher.stopCh = make(chan struct{})
+	u.publisher.doneCh = make(chan struct{})
+	go u.publisher.run(ctx)
+
+	return nil
+}
+
+// Stop signals the background flush loop to commit whatever is still
+// buffered and exit, and blocks until it has.
+func (u *Updater) Stop() {
+	if u.publisher.stopCh == nil {
+		return
+	}
+	close(u.publisher.stopCh)
+	<-u.publisher.doneCh
 }
 
 func (u *Updater) processScanResultsCommon(jobID string, merge bool, taskType string) error {
@@ -112,20 +127,38 @@ func (u *Updater) processScanResultsCommon(jobID string, merge bool, taskType st
 		return fmt.Errorf("prepare content failed: %w", err)
 	}
 
-	if err := u.updateFileViaGit(jobID, results, merge); err != nil {
-		return fmt.Errorf("git update failed: %w", err)
+	if err := u.persist(jobID, results, merge); err != nil {
+		return fmt.Errorf("store update failed: %w", err)
 	}
 
-	u.logger.Info("Successfully updated results on GitHub",
+	u.logger.Info("Successfully updated results",
 		zap.String("job_id", jobID),
-		zap.String("task_type", taskType),
-		zap.String("repo", fmt.Sprintf("%s/%s", u.repoOwner, u.repoName)))
+		zap.String("task_type", taskType))
 
 	return nil
 }
 
+// ProcessScanResults reads jobID's results and enqueues them for the next
+// coalesced flush (see Start/publisher) instead of merging and committing
+// immediately - scan jobs complete far more often than refreshes, so a
+// clone/merge/push per job would serialize them all behind a slow git push.
 func (u *Updater) ProcessScanResults(jobID string) error {
-	return u.processScanResultsCommon(jobID, true, "scan")
+	resultsData, err := u.fetchResults(jobID)
+	if err != nil {
+		return fmt.Errorf("fetch results failed: %w", err)
+	}
+
+	results, err := u.prepareContent(resultsData)
+	if err != nil {
+		return fmt.Errorf("prepare content failed: %w", err)
+	}
+
+	if err := u.publisher.enqueue(context.Background(), jobID, results); err != nil {
+		return fmt.Errorf("enqueue scan results failed: %w", err)
+	}
+
+	u.logger.Info("Enqueued scan results for coalesced flush", zap.String("job_id", jobID))
+	return nil
 }
 
 func (u *Updater) ProcessRefreshResults(jobID string) error {
@@ -149,28 +182,17 @@ func (u *Updater) prepareContent(resultsData []byte) (JSONResult, error) {
 	return formatResultsJSON(scanResult), nil
 }
 
-func (u *Updater) updateFileViaGit(jobID string, current JSONResult, merge bool) error {
-	os.RemoveAll(u.workDir)
-	defer os.RemoveAll(u.workDir)
-
-	repo, err := git.PlainClone(u.workDir, false, &git.CloneOptions{
-		URL:   u.repoURL,
-		Auth:  u.auth,
-		Depth: CLONE_DEPTH,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to clone repository: %w", err)
-	}
-
-	filePath := filepath.Join(u.workDir, FILENAME)
+func (u *Updater) persist(jobID string, current JSONResult, merge bool) error {
+	ctx := context.Background()
 
 	if merge {
-		if err := u.mergeExistingContent(filePath, &current); err != nil {
+		if err := u.mergeExistingContent(ctx, &current); err != nil {
 			u.logger.Warn("Failed to merge existing content.", zap.Error(err))
 		}
 	}
 
-	if err := u.writeEncryptedContent(filePath, current); err != nil {
+	encoded, err := u.encodeContent(current)
+	if err != nil {
 		return err
 	}
 
@@ -178,7 +200,7 @@ func (u *Updater) updateFileViaGit(jobID string, current JSONResult, merge bool)
 		return current.Results[i].Delay < current.Results[j].Delay
 	})
 
-	return u.commitAndPush(repo, jobID)
+	return u.store.Put(ctx, jobID, encoded)
 }
 
 func (u *Updater) hashConfig(config string) string {
@@ -186,27 +208,20 @@ func (u *Updater) hashConfig(config string) string {
 	return hex.EncodeToString(sum[:])
 }
 
-func (u *Updater) mergeExistingContent(filePath string, current *JSONResult) error {
-	content, err := os.ReadFile(filePath)
+func (u *Updater) mergeExistingContent(ctx context.Context, current *JSONResult) error {
+	content, err := u.store.Get(ctx)
 	if err != nil {
 		return err
 	}
-
-	decoded, err := base64.StdEncoding.DecodeString(string(content))
-	if err != nil {
-		return err
+	if content == nil {
+		return nil
 	}
 
-	decrypted, err := crypto.Decrypt(decoded, u.encryptionKey)
+	existing, err := u.decodeContent(content)
 	if err != nil {
 		return err
 	}
 
-	var existing JSONResult
-	if err := json.Unmarshal(decrypted, &existing); err != nil {
-		return err
-	}
-
 	if len(existing.Results) == 0 {
 		return nil
 	}
@@ -225,105 +240,66 @@ func (u *Updater) mergeExistingContent(filePath string, current *JSONResult) err
 	return nil
 }
 
-func (u *Updater) writeEncryptedContent(filePath string, content JSONResult) error {
+func (u *Updater) encodeContent(content JSONResult) ([]byte, error) {
 	jsonContent, err := json.Marshal(content)
 	if err != nil {
-		return fmt.Errorf("marshal content: %w", err)
-	}
-
-	encrypted, err := crypto.Encrypt(jsonContent, u.encryptionKey)
-	if err != nil {
-		return fmt.Errorf("encrypt content: %w", err)
+		return nil, fmt.Errorf("marshal content: %w", err)
 	}
 
-	return os.WriteFile(filePath, []byte(base64.StdEncoding.EncodeToString(encrypted)), FILE_PERMS)
-}
-
-func (u *Updater) commitAndPush(repo *git.Repository, jobID string) error {
-	worktree, err := repo.Worktree()
+	compressed, err := compress(jsonContent, u.compression)
 	if err != nil {
-		return fmt.Errorf("failed to get worktree: %w", err)
+		return nil, fmt.Errorf("compress content: %w", err)
 	}
 
-	if _, err := worktree.Add(FILENAME); err != nil {
-		return fmt.Errorf("failed to add file: %w", err)
+	var encrypted []byte
+	if u.envelopeMode {
+		encrypted, err = u.keyring.EncryptDEK(compressed)
+	} else {
+		encrypted, err = u.keyring.Encrypt(compressed)
 	}
-
-	_, err = worktree.Commit(fmt.Sprintf("🤖 Update scan results - Job %s", jobID), &git.CommitOptions{
-		Author: &object.Signature{
-			Name:  BOT_NAME,
-			Email: BOT_EMAIL,
-			When:  time.Now(),
-		},
-	})
 	if err != nil {
-		return fmt.Errorf("failed to commit: %w", err)
+		return nil, fmt.Errorf("encrypt content: %w", err)
 	}
 
-	if err := repo.Push(&git.PushOptions{
-		RemoteName: REMOTE_NAME,
-		Auth:       u.auth,
-	}); err != nil {
-		return fmt.Errorf("failed to push: %w", err)
-	}
-	return nil
+	return []byte(base64.StdEncoding.EncodeToString(encrypted)), nil
 }
 
-func (u *Updater) GetCurrentConfigsViaHTTP() ([]string, error) {
-	rawURL := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/main/%s",
-		u.repoOwner, u.repoName, FILENAME)
-
-	u.logger.Debug("Fetching configs via HTTP", zap.String("url", rawURL))
-
-	req, err := http.NewRequest(http.MethodGet, rawURL, http.NoBody)
+func (u *Updater) decodeContent(content []byte) (JSONResult, error) {
+	decoded, err := base64.StdEncoding.DecodeString(string(content))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		return JSONResult{}, fmt.Errorf("failed to decode content: %w", err)
 	}
 
-	req.Header.Set("User-Agent", "namira-core/1.1.0")
-	req.Header.Set("Accept", "text/plain")
-
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
-
-	resp, err := client.Do(req)
+	decrypted, err := u.keyring.Decrypt(decoded)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch file: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusNotFound {
-		u.logger.Info("No existing config file found, starting fresh")
-		return []string{}, nil
+		return JSONResult{}, fmt.Errorf("failed to decrypt content: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error: %d %s", resp.StatusCode, resp.Status)
+	decompressed, err := decompress(decrypted)
+	if err != nil {
+		return JSONResult{}, fmt.Errorf("failed to decompress content: %w", err)
 	}
 
-	content, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	var result JSONResult
+	if err := json.Unmarshal(decompressed, &result); err != nil {
+		return JSONResult{}, fmt.Errorf("failed to unmarshal content: %w", err)
 	}
 
-	return u.decryptAndParseConfigs(content)
+	return result, nil
 }
 
-func (u *Updater) decryptAndParseConfigs(content []byte) ([]string, error) {
-	decoded, err := base64.StdEncoding.DecodeString(string(content))
+func (u *Updater) GetCurrentConfigs() ([]string, error) {
+	content, err := u.store.Get(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode content: %w", err)
+		return nil, fmt.Errorf("failed to fetch configs: %w", err)
 	}
-
-	decrypted, err := crypto.Decrypt(decoded, u.encryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt content: %w", err)
+	if content == nil {
+		return []string{}, nil
 	}
 
-	var existing JSONResult
-	if err := json.Unmarshal(decrypted, &existing); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal content: %w", err)
+	existing, err := u.decodeContent(content)
+	if err != nil {
+		return nil, err
 	}
 
 	configs := make([]string, len(existing.Results))
@@ -335,36 +311,6 @@ func (u *Updater) decryptAndParseConfigs(content []byte) ([]string, error) {
 	return configs, nil
 }
 
-func (u *Updater) GetCurrentConfigsViaGit() ([]string, error) {
-	os.RemoveAll(u.workDir)
-	defer os.RemoveAll(u.workDir)
-
-	_, err := git.PlainClone(u.workDir, false, &git.CloneOptions{
-		URL:   u.repoURL,
-		Auth:  u.auth,
-		Depth: CLONE_DEPTH,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to clone repository: %w", err)
-	}
-
-	content, err := os.ReadFile(filepath.Join(u.workDir, FILENAME))
-	if err != nil {
-		return []string{}, nil
-	}
-
-	return u.decryptAndParseConfigs(content)
-}
-
-func (u *Updater) GetCurrentConfigs() ([]string, error) {
-	configs, err := u.GetCurrentConfigsViaHTTP()
-	if err != nil {
-		u.logger.Warn("HTTP fetch failed, falling back to Git clone", zap.Error(err))
-		return u.GetCurrentConfigsViaGit()
-	}
-	return configs, nil
-}
-
 func formatResultsJSON(scanResult ScanResult) JSONResult {
 	results := make([]JSONConfigResult, 0, len(scanResult.Results))
 	for _, result := range scanResult.Results {
@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Build constructs a NotifierRegistry from cfg, instantiating one Notifier
+// per channel per its Type and registering it with that channel's filter
+// and rate limit.
+func Build(cfg *RegistryConfig, logger *zap.Logger) (*NotifierRegistry, error) {
+	registry := NewNotifierRegistry(logger)
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	for _, ch := range cfg.Channels {
+		notifier, err := buildNotifier(ch, logger, client)
+		if err != nil {
+			return nil, fmt.Errorf("channel %q: %w", ch.Name, err)
+		}
+		registry.Register(ch.Name, notifier, ch.Filter, ch.RateLimit, ch.Retry)
+	}
+
+	return registry, nil
+}
+
+func buildNotifier(ch ChannelConfig, logger *zap.Logger, client *http.Client) (Notifier, error) {
+	switch ch.Type {
+	case "telegram":
+		if ch.Telegram == nil {
+			return nil, fmt.Errorf("telegram channel requires a telegram config block")
+		}
+		return NewTelegram(ch.Telegram.BotToken, ch.Telegram.Channel, ch.Telegram.Template, ch.Telegram.QRConfig, client), nil
+	case "discord":
+		if ch.Webhook == nil {
+			return nil, fmt.Errorf("discord channel requires a webhook config block")
+		}
+		return NewDiscordWebhook(ch.Webhook.URL, ch.Webhook.QRConfig, client), nil
+	case "slack":
+		if ch.Webhook == nil {
+			return nil, fmt.Errorf("slack channel requires a webhook config block")
+		}
+		return NewSlackWebhook(ch.Webhook.URL, ch.Webhook.QRConfig, client), nil
+	case "mattermost":
+		if ch.Webhook == nil {
+			return nil, fmt.Errorf("mattermost channel requires a webhook config block")
+		}
+		return NewMattermostWebhook(ch.Webhook.URL, ch.Webhook.QRConfig, client), nil
+	case "http":
+		if ch.HTTP == nil {
+			return nil, fmt.Errorf("http channel requires an http config block")
+		}
+		return NewHTTPNotifier(ch.HTTP.URL, ch.HTTP.Method, ch.HTTP.Secret, ch.HTTP.Headers, client), nil
+	case "log":
+		return NewLogNotifier(logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported channel type: %s", ch.Type)
+	}
+}
@@ -0,0 +1,95 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RegistryConfig is the on-disk definition of every channel a
+// NotifierRegistry dispatches to, loaded from a YAML or JSON file (picked by
+// extension) rather than the env-var-driven internal/config.Config, since
+// the channel list is plugin-style data an operator edits independently of
+// the rest of the server config.
+type RegistryConfig struct {
+	Channels []ChannelConfig `yaml:"channels" json:"channels"`
+}
+
+// ChannelConfig describes one notification channel: its transport, the
+// results it should fire for, and how often it may fire. Exactly one of
+// Telegram/Webhook/HTTP should be set, matching Type.
+type ChannelConfig struct {
+	Name      string          `yaml:"name" json:"name"`
+	Type      string          `yaml:"type" json:"type"` // telegram, discord, slack, mattermost, http, log
+	Telegram  *TelegramConfig `yaml:"telegram,omitempty" json:"telegram,omitempty"`
+	Webhook   *WebhookConfig  `yaml:"webhook,omitempty" json:"webhook,omitempty"` // discord, slack, mattermost
+	HTTP      *HTTPConfig     `yaml:"http,omitempty" json:"http,omitempty"`
+	Filter    FilterConfig    `yaml:"filter" json:"filter"`
+	RateLimit RateLimitConfig `yaml:"rate_limit" json:"rate_limit"`
+	Retry     RetryConfig     `yaml:"retry" json:"retry"`
+}
+
+// TelegramConfig mirrors the fields NewTelegram already takes.
+type TelegramConfig struct {
+	BotToken string `yaml:"bot_token" json:"bot_token"`
+	Channel  string `yaml:"channel" json:"channel"`
+	Template string `yaml:"template" json:"template"`
+	QRConfig string `yaml:"qr_config" json:"qr_config"`
+}
+
+// WebhookConfig configures a Discord or Slack incoming webhook.
+type WebhookConfig struct {
+	URL      string `yaml:"url" json:"url"`
+	QRConfig string `yaml:"qr_config,omitempty" json:"qr_config,omitempty"`
+}
+
+// HTTPConfig configures a generic outbound POST for integrations with no
+// dedicated transport here. When Secret is set, the request body is signed
+// with HMAC-SHA256 in the X-Namira-Signature header.
+type HTTPConfig struct {
+	URL     string            `yaml:"url" json:"url"`
+	Method  string            `yaml:"method,omitempty" json:"method,omitempty"`
+	Secret  string            `yaml:"secret,omitempty" json:"secret,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+}
+
+// FilterConfig limits which CheckResults a channel fires for. Deny always
+// wins over Allow; an empty Allow list means "no restriction".
+type FilterConfig struct {
+	MinDelay       time.Duration `yaml:"min_delay,omitempty" json:"min_delay,omitempty"`
+	AllowCountries []string      `yaml:"allow_countries,omitempty" json:"allow_countries,omitempty"`
+	DenyCountries  []string      `yaml:"deny_countries,omitempty" json:"deny_countries,omitempty"`
+	AllowProtocols []string      `yaml:"allow_protocols,omitempty" json:"allow_protocols,omitempty"`
+	DenyProtocols  []string      `yaml:"deny_protocols,omitempty" json:"deny_protocols,omitempty"`
+}
+
+// RateLimitConfig bounds how often a channel may fire, as a token bucket.
+// Zero PerSecond disables rate limiting for that channel.
+type RateLimitConfig struct {
+	PerSecond float64 `yaml:"per_second,omitempty" json:"per_second,omitempty"`
+	Burst     int     `yaml:"burst,omitempty" json:"burst,omitempty"`
+}
+
+// LoadConfig reads a RegistryConfig from path, parsing as JSON if it ends in
+// ".json" and as YAML otherwise.
+func LoadConfig(path string) (*RegistryConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read notify config: %w", err)
+	}
+
+	var cfg RegistryConfig
+	if strings.HasSuffix(path, ".json") {
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("parse notify config as JSON: %w", err)
+		}
+	} else if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse notify config as YAML: %w", err)
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/NaMiraNet/namira-core/internal/core"
+	"github.com/NaMiraNet/namira-core/internal/qr"
+)
+
+// DiscordWebhook posts a CheckResult to a Discord incoming webhook as a
+// single embed, optionally attaching a QR code image of the raw link.
+type DiscordWebhook struct {
+	URL         string
+	Client      *http.Client
+	qrGenerator *qr.QRGenerator
+}
+
+func NewDiscordWebhook(webhookURL, qrConfig string, client *http.Client) *DiscordWebhook {
+	return &DiscordWebhook{
+		URL:         webhookURL,
+		Client:      client,
+		qrGenerator: qr.NewQRGenerator(qrConfig),
+	}
+}
+
+func (d *DiscordWebhook) Name() string { return "discord" }
+
+type discordImage struct {
+	URL string `json:"url"`
+}
+
+type discordEmbed struct {
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Color       int           `json:"color"`
+	Image       *discordImage `json:"image,omitempty"`
+}
+
+type discordPayload struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+func discordEmbedFor(result core.CheckResult) discordEmbed {
+	title := result.Remark
+	if title == "" {
+		title = result.Server
+	}
+	return discordEmbed{
+		Title:       title,
+		Description: fmt.Sprintf("```\n%s\n```", result.Raw),
+		Color:       0x2ecc71,
+	}
+}
+
+func (d *DiscordWebhook) Send(result core.CheckResult) error {
+	return d.post(discordPayload{Embeds: []discordEmbed{discordEmbedFor(result)}})
+}
+
+func (d *DiscordWebhook) SendWithQRCode(result core.CheckResult) error {
+	embed := discordEmbedFor(result)
+	if result.Raw != "" {
+		embed.Image = &discordImage{URL: d.qrGenerator.GenerateURL(result.Raw)}
+	}
+	return d.post(discordPayload{Embeds: []discordEmbed{embed}})
+}
+
+func (d *DiscordWebhook) post(payload discordPayload) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, d.URL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("discord webhook returned status code: %d", resp.StatusCode)
+	}
+	return nil
+}
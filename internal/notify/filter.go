@@ -0,0 +1,41 @@
+package notify
+
+import (
+	"strings"
+
+	"github.com/NaMiraNet/namira-core/internal/core"
+)
+
+// matches reports whether result passes f: fast enough, and not excluded by
+// the country/protocol allow/deny lists.
+func (f FilterConfig) matches(result core.CheckResult) bool {
+	if f.MinDelay > 0 && result.RealDelay < f.MinDelay {
+		return false
+	}
+	if !listAllows(f.AllowCountries, f.DenyCountries, result.CountryCode) {
+		return false
+	}
+	if !listAllows(f.AllowProtocols, f.DenyProtocols, result.Protocol) {
+		return false
+	}
+	return true
+}
+
+// listAllows reports whether value passes an allow/deny pair: deny always
+// wins, and an empty allow list means "no restriction".
+func listAllows(allow, deny []string, value string) bool {
+	for _, d := range deny {
+		if strings.EqualFold(d, value) {
+			return false
+		}
+	}
+	if len(allow) == 0 {
+		return true
+	}
+	for _, a := range allow {
+		if strings.EqualFold(a, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/NaMiraNet/namira-core/internal/core"
+)
+
+// HTTPNotifier posts the raw CheckResult as JSON to an arbitrary endpoint,
+// for integrations with no dedicated transport here. When Secret is set,
+// the body is signed with HMAC-SHA256 so the receiving endpoint can verify
+// the payload actually came from namira-core.
+type HTTPNotifier struct {
+	URL     string
+	Method  string
+	Headers map[string]string
+	Secret  string
+	Client  *http.Client
+}
+
+func NewHTTPNotifier(url, method, secret string, headers map[string]string, client *http.Client) *HTTPNotifier {
+	if method == "" {
+		method = http.MethodPost
+	}
+	return &HTTPNotifier{URL: url, Method: method, Secret: secret, Headers: headers, Client: client}
+}
+
+func (h *HTTPNotifier) Name() string { return "http" }
+
+func (h *HTTPNotifier) Send(result core.CheckResult) error {
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequest(h.Method, h.URL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range h.Headers {
+		req.Header.Set(k, v)
+	}
+	if h.Secret != "" {
+		req.Header.Set("X-Namira-Signature", signHMAC(h.Secret, jsonData))
+	}
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("http notifier endpoint returned status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SendWithQRCode is identical to Send: the receiving endpoint gets the full
+// CheckResult (including Raw) and can derive its own QR code if it wants one.
+func (h *HTTPNotifier) SendWithQRCode(result core.CheckResult) error {
+	return h.Send(result)
+}
+
+// signHMAC returns the hex-encoded HMAC-SHA256 of body keyed by secret, for
+// the X-Namira-Signature header.
+func signHMAC(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,32 @@
+package notify
+
+import (
+	"github.com/NaMiraNet/namira-core/internal/core"
+	"go.uber.org/zap"
+)
+
+// LogNotifier just logs a CheckResult instead of sending it anywhere. Useful
+// as a channel type for local testing or as a fallback when no real
+// transport is configured.
+type LogNotifier struct {
+	logger *zap.Logger
+}
+
+func NewLogNotifier(logger *zap.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+func (l *LogNotifier) Name() string { return "log" }
+
+func (l *LogNotifier) Send(result core.CheckResult) error {
+	l.logger.Info("notify: check result",
+		zap.String("server", result.Server),
+		zap.String("protocol", result.Protocol),
+		zap.String("country", result.CountryCode),
+		zap.Duration("delay", result.RealDelay))
+	return nil
+}
+
+func (l *LogNotifier) SendWithQRCode(result core.CheckResult) error {
+	return l.Send(result)
+}
@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/NaMiraNet/namira-core/internal/core"
+	"github.com/NaMiraNet/namira-core/internal/qr"
+)
+
+// MattermostWebhook posts a CheckResult to a Mattermost incoming webhook as
+// a single markdown message, optionally embedding a QR code image of the
+// raw link.
+type MattermostWebhook struct {
+	URL         string
+	Client      *http.Client
+	qrGenerator *qr.QRGenerator
+}
+
+func NewMattermostWebhook(webhookURL, qrConfig string, client *http.Client) *MattermostWebhook {
+	return &MattermostWebhook{
+		URL:         webhookURL,
+		Client:      client,
+		qrGenerator: qr.NewQRGenerator(qrConfig),
+	}
+}
+
+func (m *MattermostWebhook) Name() string { return "mattermost" }
+
+type mattermostPayload struct {
+	Text string `json:"text"`
+}
+
+func mattermostTextFor(result core.CheckResult) string {
+	title := result.Remark
+	if title == "" {
+		title = result.Server
+	}
+	return fmt.Sprintf("**%s** (%s, %s)\n```\n%s\n```", title, result.Protocol, result.CountryCode, result.Raw)
+}
+
+func (m *MattermostWebhook) Send(result core.CheckResult) error {
+	return m.post(mattermostPayload{Text: mattermostTextFor(result)})
+}
+
+func (m *MattermostWebhook) SendWithQRCode(result core.CheckResult) error {
+	text := mattermostTextFor(result)
+	if result.Raw != "" {
+		text += fmt.Sprintf("\n![qr code](%s)", m.qrGenerator.GenerateURL(result.Raw))
+	}
+	return m.post(mattermostPayload{Text: text})
+}
+
+func (m *MattermostWebhook) post(payload mattermostPayload) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, m.URL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("mattermost webhook returned status code: %d", resp.StatusCode)
+	}
+	return nil
+}
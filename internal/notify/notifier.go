@@ -1,8 +1,11 @@
 package notify
 
-import "github.com/NamiraNet/namira-core/internal/core"
+import "github.com/NaMiraNet/namira-core/internal/core"
 
 type Notifier interface {
 	Send(result core.CheckResult) error
 	SendWithQRCode(result core.CheckResult) error
+	// Name identifies the backend for logging and retry accounting, e.g.
+	// "telegram" or "discord".
+	Name() string
 }
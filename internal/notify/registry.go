@@ -0,0 +1,81 @@
+package notify
+
+import (
+	"sync"
+
+	"github.com/NaMiraNet/namira-core/internal/core"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// channel pairs a Notifier with the filter/rate-limit gating whether a
+// given CheckResult reaches it.
+type channel struct {
+	name    string
+	notify  Notifier
+	filter  FilterConfig
+	limiter *rate.Limiter
+	retry   RetryConfig
+}
+
+// NotifierRegistry fans a CheckResult out to every registered channel whose
+// filter matches. Channels self-register once at startup and Dispatch never
+// needs to know their concrete transport — the same plugin-style
+// registration Nomad uses for its task-driver subsystem.
+type NotifierRegistry struct {
+	mu       sync.RWMutex
+	channels []*channel
+	logger   *zap.Logger
+}
+
+// NewNotifierRegistry returns an empty registry; use Register (or Build) to
+// add channels before calling Dispatch.
+func NewNotifierRegistry(logger *zap.Logger) *NotifierRegistry {
+	return &NotifierRegistry{logger: logger}
+}
+
+// Register adds a channel to the registry. name is used only for logging.
+// A zero RateLimitConfig.PerSecond leaves the channel unthrottled, and a
+// zero RetryConfig sends each result at most once.
+func (r *NotifierRegistry) Register(name string, notifier Notifier, filter FilterConfig, limit RateLimitConfig, retry RetryConfig) {
+	var limiter *rate.Limiter
+	if limit.PerSecond > 0 {
+		burst := limit.Burst
+		if burst <= 0 {
+			burst = 1
+		}
+		limiter = rate.NewLimiter(rate.Limit(limit.PerSecond), burst)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.channels = append(r.channels, &channel{name: name, notify: notifier, filter: filter, limiter: limiter, retry: retry})
+}
+
+// Dispatch fans result out to every registered channel whose filter
+// matches and whose rate limiter currently allows it, each in its own
+// goroutine (retried with exponential backoff on failure) so a slow or
+// failing channel can't delay the others.
+func (r *NotifierRegistry) Dispatch(result core.CheckResult) {
+	r.mu.RLock()
+	channels := make([]*channel, len(r.channels))
+	copy(channels, r.channels)
+	r.mu.RUnlock()
+
+	for _, ch := range channels {
+		if !ch.filter.matches(result) {
+			continue
+		}
+		if ch.limiter != nil && !ch.limiter.Allow() {
+			continue
+		}
+
+		go func(ch *channel) {
+			if err := sendWithRetry(ch.notify, result, ch.retry); err != nil {
+				r.logger.Error("notification channel failed after retries",
+					zap.String("channel", ch.name),
+					zap.Error(err))
+			}
+		}(ch)
+	}
+}
@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"time"
+
+	"github.com/NaMiraNet/namira-core/internal/core"
+)
+
+// RetryConfig bounds how many times a failed notifier send is retried, with
+// the delay between attempts doubling each time. A zero value sends once
+// with no retry.
+type RetryConfig struct {
+	MaxAttempts int           `yaml:"max_attempts,omitempty" json:"max_attempts,omitempty"`
+	BaseDelay   time.Duration `yaml:"base_delay,omitempty" json:"base_delay,omitempty"`
+}
+
+func (r RetryConfig) orDefaults() RetryConfig {
+	if r.MaxAttempts <= 0 {
+		r.MaxAttempts = 1
+	}
+	if r.BaseDelay <= 0 {
+		r.BaseDelay = 500 * time.Millisecond
+	}
+	return r
+}
+
+// sendWithRetry calls notifier.SendWithQRCode, retrying with exponential
+// backoff up to retry.MaxAttempts times.
+func sendWithRetry(notifier Notifier, result core.CheckResult, retry RetryConfig) error {
+	retry = retry.orDefaults()
+
+	delay := retry.BaseDelay
+	var err error
+	for attempt := 1; attempt <= retry.MaxAttempts; attempt++ {
+		if err = notifier.SendWithQRCode(result); err == nil {
+			return nil
+		}
+		if attempt < retry.MaxAttempts {
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+	return err
+}
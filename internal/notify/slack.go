@@ -0,0 +1,94 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/NaMiraNet/namira-core/internal/core"
+	"github.com/NaMiraNet/namira-core/internal/qr"
+)
+
+// SlackWebhook posts a CheckResult to a Slack incoming webhook as a Block
+// Kit message, optionally attaching a QR code image of the raw link.
+type SlackWebhook struct {
+	URL         string
+	Client      *http.Client
+	qrGenerator *qr.QRGenerator
+}
+
+func NewSlackWebhook(webhookURL, qrConfig string, client *http.Client) *SlackWebhook {
+	return &SlackWebhook{
+		URL:         webhookURL,
+		Client:      client,
+		qrGenerator: qr.NewQRGenerator(qrConfig),
+	}
+}
+
+func (s *SlackWebhook) Name() string { return "slack" }
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type     string     `json:"type"`
+	Text     *slackText `json:"text,omitempty"`
+	ImageURL string     `json:"image_url,omitempty"`
+	AltText  string     `json:"alt_text,omitempty"`
+}
+
+type slackPayload struct {
+	Blocks []slackBlock `json:"blocks"`
+}
+
+func slackMessageFor(result core.CheckResult) string {
+	title := result.Remark
+	if title == "" {
+		title = result.Server
+	}
+	return fmt.Sprintf("*%s* (%s, %s)\n```\n%s\n```", title, result.Protocol, result.CountryCode, result.Raw)
+}
+
+func (s *SlackWebhook) Send(result core.CheckResult) error {
+	payload := slackPayload{Blocks: []slackBlock{
+		{Type: "section", Text: &slackText{Type: "mrkdwn", Text: slackMessageFor(result)}},
+	}}
+	return s.post(payload)
+}
+
+func (s *SlackWebhook) SendWithQRCode(result core.CheckResult) error {
+	blocks := []slackBlock{
+		{Type: "section", Text: &slackText{Type: "mrkdwn", Text: slackMessageFor(result)}},
+	}
+	if result.Raw != "" {
+		blocks = append(blocks, slackBlock{Type: "image", ImageURL: s.qrGenerator.GenerateURL(result.Raw), AltText: "QR code"})
+	}
+	return s.post(slackPayload{Blocks: blocks})
+}
+
+func (s *SlackWebhook) post(payload slackPayload) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.URL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned status code: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -35,6 +35,8 @@ func NewTelegram(botToken, channel, template, qrConfig string, client *http.Clie
 	return t
 }
 
+func (t *Telegram) Name() string { return "telegram" }
+
 type telegramMessage struct {
 	ChatID    string `json:"chat_id"`
 	Text      string `json:"text"`
@@ -55,6 +57,12 @@ func (t *Telegram) initTemplate() {
 				return emoji.Shield.String()
 			case "shadowsocks":
 				return emoji.Locked.String()
+			case "hysteria2":
+				return emoji.SatelliteAntenna.String()
+			case "tuic":
+				return emoji.Key.String()
+			case "wg", "wireguard":
+				return emoji.Package.String()
 			default:
 				return emoji.RepeatButton.String()
 			}
@@ -0,0 +1,95 @@
+// Package redisclient builds a redis.UniversalClient from the application's
+// RedisConfig, so callers are agnostic to whether Redis is deployed as a
+// single instance, a Sentinel-managed group, or a Cluster.
+package redisclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/NaMiraNet/namira-core/internal/config"
+	"github.com/redis/go-redis/v9"
+)
+
+// New builds a redis.UniversalClient for the topology selected by cfg.Mode.
+func New(cfg config.RedisConfig) (redis.UniversalClient, error) {
+	tlsConfig, err := buildTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Redis TLS config: %w", err)
+	}
+
+	switch cfg.Mode {
+	case config.RedisModeSentinel:
+		if cfg.SentinelMaster == "" {
+			return nil, fmt.Errorf("redis: sentinel mode requires SentinelMaster")
+		}
+		if len(cfg.SentinelAddrs) == 0 {
+			return nil, fmt.Errorf("redis: sentinel mode requires at least one sentinel address")
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.SentinelMaster,
+			SentinelAddrs:    cfg.SentinelAddrs,
+			SentinelPassword: cfg.SentinelPassword,
+			Password:         cfg.Password,
+			DB:               cfg.DB,
+			TLSConfig:        tlsConfig,
+		}), nil
+
+	case config.RedisModeCluster:
+		if len(cfg.ClusterAddrs) == 0 {
+			return nil, fmt.Errorf("redis: cluster mode requires at least one seed address")
+		}
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:          cfg.ClusterAddrs,
+			Password:       cfg.Password,
+			RouteByLatency: cfg.RouteByLatency,
+			RouteRandomly:  cfg.RouteRandomly,
+			TLSConfig:      tlsConfig,
+		}), nil
+
+	case config.RedisModeSingle, "":
+		return redis.NewClient(&redis.Options{
+			Addr:      cfg.Addr,
+			Password:  cfg.Password,
+			DB:        cfg.DB,
+			TLSConfig: tlsConfig,
+		}), nil
+
+	default:
+		return nil, fmt.Errorf("redis: unsupported mode %q", cfg.Mode)
+	}
+}
+
+func buildTLSConfig(cfg config.RedisTLSConfig) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		caCert, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("failed to parse CA file: %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" && cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/NaMiraNet/namira-core/internal/core/parser"
+	"golang.org/x/net/http2"
+)
+
+// streamInfo is the subset of a parsed Config's xray-core outbound JSON
+// (see parser.Config.MarshalJSON) that testConnection needs to decide which
+// handshake to perform. parser's per-protocol configs are unexported, so
+// reading their own MarshalJSON output back is the only way to get at
+// network/security/host/path generically across vmess/vless/trojan.
+type streamInfo struct {
+	Network     string
+	Security    string
+	SNI         string
+	Host        string
+	Path        string
+	ServiceName string
+}
+
+// parseStreamInfo re-parses config's MarshalJSON output to recover the
+// transport/security details testConnection needs to probe it for real.
+func parseStreamInfo(config parser.Config) (streamInfo, error) {
+	data, err := config.MarshalJSON()
+	if err != nil {
+		return streamInfo{}, fmt.Errorf("marshal outbound config: %w", err)
+	}
+
+	var outbound struct {
+		StreamSettings struct {
+			Network    string `json:"network"`
+			Security   string `json:"security"`
+			WSSettings struct {
+				Path    string            `json:"path"`
+				Host    string            `json:"host"`
+				Headers map[string]string `json:"headers"`
+			} `json:"wsSettings"`
+			TLSSettings struct {
+				ServerName string `json:"serverName"`
+			} `json:"tlsSettings"`
+			RealitySettings struct {
+				ServerName string `json:"serverName"`
+			} `json:"realitySettings"`
+			GRPCSettings struct {
+				ServiceName string `json:"serviceName"`
+			} `json:"grpcSettings"`
+		} `json:"streamSettings"`
+	}
+	if err := json.Unmarshal(data, &outbound); err != nil {
+		return streamInfo{}, fmt.Errorf("unmarshal outbound config: %w", err)
+	}
+
+	info := streamInfo{
+		Network:     outbound.StreamSettings.Network,
+		Security:    outbound.StreamSettings.Security,
+		Host:        outbound.StreamSettings.WSSettings.Host,
+		Path:        outbound.StreamSettings.WSSettings.Path,
+		ServiceName: outbound.StreamSettings.GRPCSettings.ServiceName,
+	}
+	if info.Host == "" {
+		info.Host = outbound.StreamSettings.WSSettings.Headers["Host"]
+	}
+
+	switch info.Security {
+	case "tls":
+		info.SNI = outbound.StreamSettings.TLSSettings.ServerName
+	case "reality":
+		info.SNI = outbound.StreamSettings.RealitySettings.ServerName
+	}
+
+	return info, nil
+}
+
+// tlsHandshake upgrades conn to TLS against info.SNI. InsecureSkipVerify is
+// intentional here - this is a reachability probe against an arbitrary user
+// link, not a connection we have any certificate to pin.
+func tlsHandshake(conn net.Conn, info streamInfo, timeout time.Duration) (*tls.Conn, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         info.SNI,
+		InsecureSkipVerify: true,
+	}
+	if info.Network == "grpc" {
+		tlsConfig.NextProtos = []string{"h2"}
+	}
+
+	tlsConn := tls.Client(conn, tlsConfig)
+	if err := tlsConn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	if err := tlsConn.HandshakeContext(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := tlsConn.SetDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+
+	return tlsConn, nil
+}
+
+// probeWebSocketUpgrade sends a real WebSocket upgrade request over conn and
+// requires a 101 Switching Protocols response, so a server that accepts the
+// TCP/TLS connection but doesn't actually speak the configured WS path
+// doesn't get reported as working.
+func probeWebSocketUpgrade(conn net.Conn, fallbackHost string, info streamInfo, timeout time.Duration) error {
+	host := info.Host
+	if host == "" {
+		host = fallbackHost
+	}
+	path := info.Path
+	if path == "" {
+		path = "/"
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "http://"+host+path, nil)
+	if err != nil {
+		return fmt.Errorf("build upgrade request: %w", err)
+	}
+	req.Header.Set("Host", host)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+	defer func() { _ = conn.SetDeadline(time.Time{}) }()
+
+	if err := req.Write(conn); err != nil {
+		return fmt.Errorf("write upgrade request: %w", err)
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		return fmt.Errorf("read upgrade response: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return fmt.Errorf("expected 101 Switching Protocols, got %s", resp.Status)
+	}
+	return nil
+}
+
+// probeGRPC opens an HTTP/2 stream over conn (which must already be TLS,
+// since the xray-core gRPC transport requires it) and checks that the
+// server answers with a real HTTP/2 response, i.e. a ":status" frame, not
+// just a raw accepted TCP connection.
+func probeGRPC(conn net.Conn, fallbackHost string, info streamInfo, timeout time.Duration) error {
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return fmt.Errorf("grpc probe requires a TLS connection")
+	}
+
+	transport := &http2.Transport{}
+	clientConn, err := transport.NewClientConn(tlsConn)
+	if err != nil {
+		return fmt.Errorf("open http2 connection: %w", err)
+	}
+	defer clientConn.Close()
+
+	host := info.Host
+	if host == "" {
+		host = fallbackHost
+	}
+	serviceName := info.ServiceName
+	if serviceName == "" {
+		serviceName = "GunService"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("https://%s/%s/Tun", host, serviceName), nil)
+	if err != nil {
+		return fmt.Errorf("build grpc probe request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+
+	resp, err := clientConn.RoundTrip(req)
+	if err != nil {
+		return fmt.Errorf("http2 round trip: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 0 {
+		return fmt.Errorf("no status received")
+	}
+	return nil
+}
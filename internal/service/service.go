@@ -2,19 +2,20 @@ package service
 
 import (
 	"bufio"
-	"encoding/base64"
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net"
 	"net/http"
-	"net/url"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/NaMiraNet/namira-core/internal/core/parser"
+	"github.com/NaMiraNet/namira-core/internal/faultproxy"
 	"github.com/gorilla/mux"
 )
 
@@ -33,6 +34,17 @@ type LinkTestResult struct {
 	Server   string `json:"server,omitempty"`
 	Port     int    `json:"port,omitempty"`
 	TestedAt string `json:"tested_at"`
+
+	// TCPMs, TLSMs, and HandshakeMs break PingMs down by stage, so a
+	// reachable-but-blocked server (TCP connects, nothing else does) can be
+	// told apart from one that actually completed the protocol handshake.
+	TCPMs       int64 `json:"tcp_ms,omitempty"`
+	TLSMs       int64 `json:"tls_ms,omitempty"`
+	HandshakeMs int64 `json:"handshake_ms,omitempty"`
+
+	// Attempts counts how many times the probe ran before settling on this
+	// result - more than 1 means earlier attempts failed and were retried.
+	Attempts int `json:"attempts"`
 }
 
 type TestResponse struct {
@@ -46,82 +58,83 @@ type TestResponse struct {
 type RayPingService struct {
 	timeout       time.Duration
 	maxConcurrent int
+	dialer        faultproxy.DialContextFunc
+	parser        *parser.Parser
+
+	// retryTimeout bounds the total time spent retrying a single link across
+	// all attempts; retrySleep is how long a failed attempt waits before the
+	// next one. retryTimeout <= 0 disables retries (one attempt only).
+	retryTimeout time.Duration
+	retrySleep   time.Duration
 }
 
-func NewRayPingService(timeout time.Duration, maxConcurrent int) *RayPingService {
-	return &RayPingService{
-		timeout:       timeout,
-		maxConcurrent: maxConcurrent,
+// Option configures optional RayPingService behavior.
+type Option func(*RayPingService)
+
+// WithDialer replaces the dialer testConnection uses to reach link servers.
+// Tests use this to inject a faultproxy.Dialer without a real flaky network.
+func WithDialer(dialer faultproxy.DialContextFunc) Option {
+	return func(rps *RayPingService) {
+		rps.dialer = dialer
 	}
 }
 
-func parseServerAndPort(u *url.URL) (string, int) {
-	port, _ := strconv.Atoi(u.Port())
-	if port == 0 {
-		port = DefaultPort
+// WithRetry retries a failed link check until retryTimeout elapses, sleeping
+// retrySleep between attempts. Retries are disabled (the default) when
+// retryTimeout <= 0.
+func WithRetry(retryTimeout, retrySleep time.Duration) Option {
+	return func(rps *RayPingService) {
+		rps.retryTimeout = retryTimeout
+		rps.retrySleep = retrySleep
 	}
-	return u.Hostname(), port
 }
 
-func (rps *RayPingService) parseLink(link string) (*LinkTestResult, error) {
+func NewRayPingService(timeout time.Duration, maxConcurrent int, opts ...Option) *RayPingService {
+	rps := &RayPingService{
+		timeout:       timeout,
+		maxConcurrent: maxConcurrent,
+		dialer:        (&net.Dialer{}).DialContext,
+		parser:        parser.NewParser(),
+	}
+	for _, opt := range opts {
+		opt(rps)
+	}
+	return rps
+}
+
+// parseLink delegates to the shared internal/core/parser package so every
+// supported protocol (including SIP002 Shadowsocks with plugins) is parsed
+// in exactly one place. It returns the parsed Config alongside the result
+// so testConnection can probe the real protocol instead of a bare TCP dial.
+func (rps *RayPingService) parseLink(link string) (*LinkTestResult, parser.Config, error) {
 	link = strings.TrimSpace(link)
 	result := &LinkTestResult{Link: link}
 
-	switch {
-	case strings.HasPrefix(link, "vmess://"):
-		encoded := strings.TrimPrefix(link, "vmess://")
-		decoded, err := base64.StdEncoding.DecodeString(encoded)
-		if err != nil {
-			return nil, fmt.Errorf("invalid vmess link: %v", err)
-		}
-
-		var config map[string]any
-		if err := json.Unmarshal(decoded, &config); err != nil {
-			return nil, fmt.Errorf("invalid vmess config: %v", err)
-		}
-
-		result.Protocol = "vmess"
-		result.Server, _ = config["add"].(string)
-		portStr, _ := config["port"].(string)
-		result.Port, _ = strconv.Atoi(portStr)
+	protocol, _, found := strings.Cut(link, "://")
+	if !found {
+		return nil, nil, fmt.Errorf("unsupported protocol")
+	}
+	result.Protocol = strings.ToLower(protocol)
 
-	case strings.HasPrefix(link, "vless://"), strings.HasPrefix(link, "trojan://"):
-		u, err := url.Parse(link)
-		if err != nil {
-			return nil, fmt.Errorf("invalid URL: %v", err)
-		}
-		result.Protocol = strings.TrimSuffix(u.Scheme, "://")
-		result.Server, result.Port = parseServerAndPort(u)
-
-	case strings.HasPrefix(link, "ss://"):
-		encoded := strings.TrimPrefix(link, "ss://")
-		result.Protocol = "ss"
-
-		if idx := strings.Index(encoded, "@"); idx != -1 {
-			serverPort := encoded[idx+1:]
-			parts := strings.Split(serverPort, ":")
-			if len(parts) >= 2 {
-				result.Server = parts[0]
-				result.Port, _ = strconv.Atoi(parts[1])
-			}
-		} else if decoded, err := base64.StdEncoding.DecodeString(encoded); err == nil {
-			if idx := strings.Index(string(decoded), "@"); idx != -1 {
-				serverPort := strings.Split(string(decoded)[idx+1:], ":")
-				if len(serverPort) >= 2 {
-					result.Server = serverPort[0]
-					result.Port, _ = strconv.Atoi(serverPort[1])
-				}
-			}
-		}
+	config, err := rps.parser.Parse(link)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid %s link: %v", result.Protocol, err)
+	}
 
-	default:
-		return nil, fmt.Errorf("unsupported protocol")
+	endpoint, ok := config.(parser.Endpoint)
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported protocol: %s", result.Protocol)
 	}
+	result.Server, result.Port = endpoint.Endpoint()
 
-	return result, nil
+	return result, config, nil
 }
 
-func (rps *RayPingService) testConnection(result *LinkTestResult) {
+// testConnection does a real protocol handshake instead of a bare TCP dial:
+// TLS (with SNI) for tls/reality security, then a WebSocket upgrade or an
+// HTTP/2 gRPC round trip for those transports, so a server that merely has
+// an open port doesn't get reported as a working node.
+func (rps *RayPingService) testConnection(ctx context.Context, result *LinkTestResult, config parser.Config) {
 	start := time.Now()
 	result.TestedAt = start.Format(time.RFC3339)
 
@@ -131,18 +144,78 @@ func (rps *RayPingService) testConnection(result *LinkTestResult) {
 		return
 	}
 
-	conn, err := net.DialTimeout("tcp", net.JoinHostPort(result.Server, strconv.Itoa(result.Port)), rps.timeout)
+	info, err := parseStreamInfo(config)
+	if err != nil {
+		result.Failed = true
+		result.Error = fmt.Sprintf("failed to inspect outbound config: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, rps.timeout)
+	defer cancel()
+
+	tcpStart := time.Now()
+	conn, err := rps.dialer(ctx, "tcp", net.JoinHostPort(result.Server, strconv.Itoa(result.Port)))
 	if err != nil {
 		result.Failed = true
 		result.Error = fmt.Sprintf("connection failed: %v", err)
 		return
 	}
 	defer conn.Close()
+	result.TCPMs = time.Since(tcpStart).Milliseconds()
+
+	if ctx.Err() != nil {
+		result.Failed = true
+		result.Error = ctx.Err().Error()
+		return
+	}
+
+	handshakeConn := conn
+	if info.Security == "tls" || info.Security == "reality" {
+		tlsStart := time.Now()
+		tlsConn, err := tlsHandshake(conn, info, rps.timeout)
+		if err != nil {
+			result.Failed = true
+			result.Error = fmt.Sprintf("tls handshake failed: %v", err)
+			return
+		}
+		result.TLSMs = time.Since(tlsStart).Milliseconds()
+		handshakeConn = tlsConn
+	}
+
+	if ctx.Err() != nil {
+		result.Failed = true
+		result.Error = ctx.Err().Error()
+		return
+	}
 
-	result.PingMs = time.Since(start).Milliseconds()
+	switch info.Network {
+	case "ws":
+		if err := probeWebSocketUpgrade(handshakeConn, result.Server, info, rps.timeout); err != nil {
+			result.Failed = true
+			result.Error = fmt.Sprintf("websocket upgrade failed: %v", err)
+			return
+		}
+	case "grpc":
+		if err := probeGRPC(handshakeConn, result.Server, info, rps.timeout); err != nil {
+			result.Failed = true
+			result.Error = fmt.Sprintf("grpc probe failed: %v", err)
+			return
+		}
+	}
+
+	result.HandshakeMs = time.Since(start).Milliseconds()
+	result.PingMs = result.HandshakeMs
+	result.Failed = false
 }
 
-func (rps *RayPingService) TestLinks(links []string) *TestResponse {
+// TestLinks runs link tests concurrently, bounded by rps.maxConcurrent, and
+// returns the aggregated TestResponse once every link has been tested. If
+// onResult is non-nil, it's also invoked with each LinkTestResult as soon as
+// it completes, which lets streaming handlers surface results incrementally
+// instead of waiting for the whole batch. A canceled ctx (e.g. the client
+// disconnected) stops any link still waiting on the semaphore or mid-probe.
+func (rps *RayPingService) TestLinks(ctx context.Context, links []string, onResult func(LinkTestResult)) *TestResponse {
 	results := make([]LinkTestResult, 0, len(links))
 	resultChan := make(chan LinkTestResult, len(links))
 	sem := make(chan struct{}, rps.maxConcurrent)
@@ -156,22 +229,59 @@ func (rps *RayPingService) TestLinks(links []string) *TestResponse {
 		wg.Add(1)
 		go func(link string) {
 			defer wg.Done()
-			sem <- struct{}{}
-			defer func() { <-sem }()
 
-			result, err := rps.parseLink(link)
+			result, config, err := rps.parseLink(link)
 			if err != nil {
-				resultChan <- LinkTestResult{
+				result = &LinkTestResult{
 					Link:     link,
 					Protocol: "unknown",
 					Failed:   true,
 					Error:    err.Error(),
 					TestedAt: time.Now().Format(time.RFC3339),
 				}
+				if onResult != nil {
+					onResult(*result)
+				}
+				resultChan <- *result
 				return
 			}
 
-			rps.testConnection(result)
+			deadline := time.Now().Add(rps.retryTimeout)
+		retryLoop:
+			for attempt := 1; ; attempt++ {
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					result.Failed = true
+					result.Error = ctx.Err().Error()
+					result.TestedAt = time.Now().Format(time.RFC3339)
+					break retryLoop
+				}
+
+				result.Attempts = attempt
+				rps.testConnection(ctx, result, config)
+				<-sem
+
+				if !result.Failed || rps.retryTimeout <= 0 {
+					break retryLoop
+				}
+				if time.Now().Add(rps.retrySleep).After(deadline) {
+					break retryLoop
+				}
+
+				select {
+				case <-time.After(rps.retrySleep):
+				case <-ctx.Done():
+					result.Failed = true
+					result.Error = ctx.Err().Error()
+					result.TestedAt = time.Now().Format(time.RFC3339)
+					break retryLoop
+				}
+			}
+
+			if onResult != nil {
+				onResult(*result)
+			}
 			resultChan <- *result
 		}(link)
 	}
@@ -234,7 +344,89 @@ func (rps *RayPingService) handleTest(w http.ResponseWriter, r *http.Request) {
 
 	log.Printf("Testing %d links", len(links))
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(rps.TestLinks(links))
+	json.NewEncoder(w).Encode(rps.TestLinks(r.Context(), links, nil))
+}
+
+// handleTestStream is the streaming counterpart to handleTest: it emits a
+// Server-Sent Event per LinkTestResult as soon as that link finishes testing,
+// followed by a terminal "summary" event carrying the totals, instead of
+// making the client wait for the whole file to be tested before seeing
+// anything. A client disconnect cancels r.Context(), which TestLinks uses to
+// stop any link still waiting on the semaphore or mid-probe.
+func (rps *RayPingService) handleTestStream(w http.ResponseWriter, r *http.Request) {
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "No file uploaded", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	var links []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			links = append(links, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		http.Error(w, "Failed to read file", http.StatusInternalServerError)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Testing %d links (stream)", len(links))
+
+	ctx := r.Context()
+	resultChan := make(chan LinkTestResult)
+	go func() {
+		defer close(resultChan)
+		rps.TestLinks(ctx, links, func(result LinkTestResult) {
+			select {
+			case resultChan <- result:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	totalTested, workingLinks := 0, 0
+	for {
+		select {
+		case result, ok := <-resultChan:
+			if !ok {
+				summary := TestResponse{
+					TotalTested:  totalTested,
+					WorkingLinks: workingLinks,
+					FailedLinks:  totalTested - workingLinks,
+					ProcessedAt:  time.Now().Format(time.RFC3339),
+				}
+				data, _ := json.Marshal(summary)
+				fmt.Fprintf(w, "event: summary\ndata: %s\n\n", data)
+				flusher.Flush()
+				return
+			}
+
+			totalTested++
+			if !result.Failed {
+				workingLinks++
+			}
+
+			data, _ := json.Marshal(result)
+			fmt.Fprintf(w, "event: result\ndata: %s\n\n", data)
+			flusher.Flush()
+		case <-ctx.Done():
+			return
+		}
+	}
 }
 
 func (rps *RayPingService) StartServer(port string) error {
@@ -256,7 +448,7 @@ func (rps *RayPingService) StartServer(port string) error {
 		json.NewEncoder(w).Encode(map[string]any{
 			"service":   "RayPing VPN Link Tester",
 			"version":   "1.0.0",
-			"endpoints": []string{"GET /health", "POST /test"},
+			"endpoints": []string{"GET /health", "POST /test", "POST /test/stream"},
 		})
 	}).Methods("GET")
 
@@ -270,6 +462,7 @@ func (rps *RayPingService) StartServer(port string) error {
 	}).Methods("GET")
 
 	router.HandleFunc("/test", rps.handleTest).Methods("POST")
+	router.HandleFunc("/test/stream", rps.handleTestStream).Methods("POST")
 
 	return (&http.Server{
 		Addr:         ":" + port,
@@ -0,0 +1,53 @@
+// Package tracing configures OpenTelemetry and exposes the tracer the
+// checking pipeline (parser.Parse, checker.CheckConfig, each outbound
+// core.Dial) uses to emit spans, so operators can see which stage is slow in
+// Jaeger/Tempo instead of grepping zap logs.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/NaMiraNet/namira-core"
+
+// Tracer is what every instrumented stage starts spans on. It points at the
+// global (no-op until Init is called) TracerProvider's tracer, so call sites
+// don't need to guard against tracing being unconfigured.
+var Tracer trace.Tracer = otel.Tracer(tracerName)
+
+// Init wires Tracer to an OTLP/gRPC exporter at endpoint (e.g.
+// "localhost:4317") and installs it as the global TracerProvider. An empty
+// endpoint is a no-op: Tracer is left pointing at the default no-op
+// provider. Call the returned shutdown func on exit to flush pending spans.
+func Init(ctx context.Context, serviceName, endpoint string) (func(context.Context) error, error) {
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("build resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	Tracer = provider.Tracer(tracerName)
+
+	return provider.Shutdown, nil
+}
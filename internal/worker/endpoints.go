@@ -0,0 +1,221 @@
+package workerpool
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Endpoint is a weighted check-server target the scheduler fans tasks out across.
+type Endpoint struct {
+	ID          string
+	Host        string
+	Port        uint32
+	Weight      int
+	Region      string
+	MaxInflight int
+}
+
+// EndpointStatus reports live scheduling stats for one endpoint.
+type EndpointStatus struct {
+	ID                  string
+	Host                string
+	Port                uint32
+	Region              string
+	Healthy             bool
+	Inflight            int
+	Dispatched          int64
+	ConsecutiveFailures int
+}
+
+type endpointState struct {
+	endpoint            Endpoint
+	inflight            int
+	dispatched          int64
+	consecutiveFailures int
+	healthy             bool
+}
+
+// spreadTolerance allows an endpoint to take a few more tasks than its exact
+// weighted share before the scheduler looks elsewhere, so small jobs still
+// spread across endpoints instead of always picking the same "emptiest" one.
+const spreadTolerance = 2
+
+// EndpointSchedulerConfig configures an EndpointScheduler.
+type EndpointSchedulerConfig struct {
+	Endpoints              []Endpoint
+	UnhealthyAfterFailures int
+	ReprobeInterval        time.Duration
+}
+
+// EndpointScheduler picks check-server endpoints for a job's tasks using
+// weighted round-robin with a spread cap: no endpoint should receive more
+// than ceil(weightShare*total)+tolerance tasks from a single job. It also
+// tracks per-endpoint inflight counts and marks an endpoint unhealthy after
+// enough consecutive failures, borrowing the affinity/spread placement idea
+// used by schedulers like Nomad's.
+type EndpointScheduler struct {
+	mu              sync.Mutex
+	endpoints       []*endpointState
+	unhealthyAfter  int
+	reprobeInterval time.Duration
+}
+
+func NewEndpointScheduler(cfg EndpointSchedulerConfig) *EndpointScheduler {
+	if cfg.UnhealthyAfterFailures <= 0 {
+		cfg.UnhealthyAfterFailures = 3
+	}
+	if cfg.ReprobeInterval <= 0 {
+		cfg.ReprobeInterval = 30 * time.Second
+	}
+
+	states := make([]*endpointState, 0, len(cfg.Endpoints))
+	for _, e := range cfg.Endpoints {
+		states = append(states, &endpointState{endpoint: e, healthy: true})
+	}
+
+	return &EndpointScheduler{
+		endpoints:       states,
+		unhealthyAfter:  cfg.UnhealthyAfterFailures,
+		reprobeInterval: cfg.ReprobeInterval,
+	}
+}
+
+// PickForJob selects an endpoint for task `index` out of `total` tasks in the
+// job. It returns nil if no endpoint is currently available.
+func (s *EndpointScheduler) PickForJob(index, total int) *Endpoint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	candidates := s.withinBudget(total)
+	if len(candidates) == 0 {
+		candidates = s.healthy()
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.inflight < best.inflight {
+			best = c
+		}
+	}
+
+	best.inflight++
+	best.dispatched++
+	ep := best.endpoint
+	return &ep
+}
+
+func (s *EndpointScheduler) totalWeight() int {
+	total := 0
+	for _, e := range s.endpoints {
+		total += e.endpoint.Weight
+	}
+	return total
+}
+
+func (s *EndpointScheduler) withinBudget(jobTotal int) []*endpointState {
+	totalWeight := s.totalWeight()
+	if totalWeight == 0 {
+		return nil
+	}
+
+	var result []*endpointState
+	for _, e := range s.healthy() {
+		share := float64(e.endpoint.Weight) / float64(totalWeight)
+		budget := int(math.Ceil(share*float64(jobTotal))) + spreadTolerance
+		if int(e.dispatched) < budget {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func (s *EndpointScheduler) healthy() []*endpointState {
+	var result []*endpointState
+	for _, e := range s.endpoints {
+		if !e.healthy {
+			continue
+		}
+		if e.endpoint.MaxInflight > 0 && e.inflight >= e.endpoint.MaxInflight {
+			continue
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+// Release decrements the inflight counter for id and records the check
+// outcome, marking the endpoint unhealthy after enough consecutive failures.
+func (s *EndpointScheduler) Release(id string, success bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range s.endpoints {
+		if e.endpoint.ID != id {
+			continue
+		}
+		if e.inflight > 0 {
+			e.inflight--
+		}
+		if success {
+			e.consecutiveFailures = 0
+			e.healthy = true
+		} else {
+			e.consecutiveFailures++
+			if e.consecutiveFailures >= s.unhealthyAfter {
+				e.healthy = false
+			}
+		}
+		return
+	}
+}
+
+// Reprobe re-checks every unhealthy endpoint with probe and restores it once
+// probe reports success. Callers should invoke this on a timer using
+// ReprobeInterval.
+func (s *EndpointScheduler) Reprobe(probe func(Endpoint) bool) {
+	s.mu.Lock()
+	var toProbe []Endpoint
+	for _, e := range s.endpoints {
+		if !e.healthy {
+			toProbe = append(toProbe, e.endpoint)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, ep := range toProbe {
+		if probe(ep) {
+			s.Release(ep.ID, true)
+		}
+	}
+}
+
+// ReprobeInterval returns the configured interval between unhealthy-endpoint reprobes.
+func (s *EndpointScheduler) ReprobeInterval() time.Duration {
+	return s.reprobeInterval
+}
+
+// Status returns a snapshot of every endpoint's scheduling stats, suitable
+// for surfacing in WorkerPoolStats and the /health response.
+func (s *EndpointScheduler) Status() []EndpointStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]EndpointStatus, 0, len(s.endpoints))
+	for _, e := range s.endpoints {
+		statuses = append(statuses, EndpointStatus{
+			ID:                  e.endpoint.ID,
+			Host:                e.endpoint.Host,
+			Port:                e.endpoint.Port,
+			Region:              e.endpoint.Region,
+			Healthy:             e.healthy,
+			Inflight:            e.inflight,
+			Dispatched:          e.dispatched,
+			ConsecutiveFailures: e.consecutiveFailures,
+		})
+	}
+	return statuses
+}
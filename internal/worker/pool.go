@@ -4,25 +4,38 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type WorkerPool struct {
-	workers     []*Worker
-	taskQueue   chan Task
-	resultQueue chan Result
-	workerCount int
-	wg          sync.WaitGroup
-	ctx         context.Context
-	cancel      context.CancelFunc
-	started     bool
-	mu          sync.RWMutex
+	workers      []*Worker
+	taskQueue    chan Task
+	resultQueue  chan Result
+	workerCount  int
+	nextWorkerID int
+	wg           sync.WaitGroup
+	ctx          context.Context
+	cancel       context.CancelFunc
+	started      bool
+	mu           sync.RWMutex
+
+	submitTimeout time.Duration
+	autoScale     AutoScaleConfig
+
+	// cancelChans backs per-task deadlines/cancellation: one entry per
+	// in-flight task, keyed by Task.ID.
+	cancelMu    sync.Mutex
+	cancelChans map[string]*taskCancel
 
 	// Metrics
-	totalTasks     int64
-	completedTasks int64
-	failedTasks    int64
-	startTime      time.Time
+	totalTasks      int64
+	completedTasks  int64
+	failedTasks     int64
+	busyWorkers     int64
+	scaleUpEvents   int64
+	scaleDownEvents int64
+	startTime       time.Time
 }
 
 // NewWorkerPool creates a new worker pool with the specified configuration
@@ -33,17 +46,45 @@ func NewWorkerPool(config WorkerPoolConfig) *WorkerPool {
 	if config.TaskQueueSize <= 0 {
 		config.TaskQueueSize = 100
 	}
+	if config.SubmitTimeout <= 0 {
+		config.SubmitTimeout = 5 * time.Second
+	}
+
+	if config.AutoScale.Enabled {
+		if config.AutoScale.MinWorkers <= 0 {
+			config.AutoScale.MinWorkers = config.WorkerCount
+		}
+		if config.AutoScale.MaxWorkers <= 0 {
+			config.AutoScale.MaxWorkers = config.AutoScale.MinWorkers * 4
+		}
+		if config.AutoScale.ScaleUpThreshold <= 0 {
+			config.AutoScale.ScaleUpThreshold = 0.75
+		}
+		if config.AutoScale.ScaleUpSampleCount <= 0 {
+			config.AutoScale.ScaleUpSampleCount = 3
+		}
+		if config.AutoScale.SampleInterval <= 0 {
+			config.AutoScale.SampleInterval = 500 * time.Millisecond
+		}
+		if config.AutoScale.IdleTimeout <= 0 {
+			config.AutoScale.IdleTimeout = 30 * time.Second
+		}
+		config.WorkerCount = config.AutoScale.MinWorkers
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &WorkerPool{
-		workers:     make([]*Worker, 0, config.WorkerCount),
-		taskQueue:   make(chan Task, config.TaskQueueSize),
-		resultQueue: make(chan Result, config.TaskQueueSize),
-		workerCount: config.WorkerCount,
-		ctx:         ctx,
-		cancel:      cancel,
-		startTime:   time.Now(),
+		workers:       make([]*Worker, 0, config.WorkerCount),
+		taskQueue:     make(chan Task, config.TaskQueueSize),
+		resultQueue:   make(chan Result, config.TaskQueueSize),
+		workerCount:   config.WorkerCount,
+		submitTimeout: config.SubmitTimeout,
+		autoScale:     config.AutoScale,
+		cancelChans:   make(map[string]*taskCancel),
+		ctx:           ctx,
+		cancel:        cancel,
+		startTime:     time.Now(),
 	}
 }
 
@@ -57,12 +98,15 @@ func (wp *WorkerPool) Start() error {
 
 	// start workers
 	for i := 0; i < wp.workerCount; i++ {
+		wp.nextWorkerID++
 		worker := &Worker{
-			ID:         i + 1,
+			ID:         wp.nextWorkerID,
 			taskChan:   wp.taskQueue,
 			resultChan: wp.resultQueue,
 			quit:       make(chan bool),
 			wg:         &wp.wg,
+			busy:       &wp.busyWorkers,
+			cancels:    wp,
 		}
 		wp.workers = append(wp.workers, worker)
 		wp.wg.Add(1)
@@ -70,6 +114,12 @@ func (wp *WorkerPool) Start() error {
 	}
 
 	wp.started = true
+
+	if wp.autoScale.Enabled {
+		wp.wg.Add(1)
+		go wp.superviseScaling()
+	}
+
 	return nil
 }
 
@@ -81,37 +131,205 @@ func (wp *WorkerPool) Submit(task Task) error {
 		return fmt.Errorf("worker pool is not started")
 	}
 
+	var timeoutChan <-chan time.Time
+	if wp.submitTimeout > 0 {
+		timer := time.NewTimer(wp.submitTimeout)
+		defer timer.Stop()
+		timeoutChan = timer.C
+	}
+
 	select {
 	case wp.taskQueue <- task:
-		wp.totalTasks++
+		atomic.AddInt64(&wp.totalTasks, 1)
 		return nil
 	case <-wp.ctx.Done():
 		return fmt.Errorf("worker pool is shutting down")
-	default:
-		return fmt.Errorf("task queue is full")
+	case <-timeoutChan:
+		return fmt.Errorf("task queue is full: timed out after %s waiting for space", wp.submitTimeout)
 	}
 }
 
 func (wp *WorkerPool) SubmitBatch(batch BatchTask) error {
-	wp.mu.RLock()
-	defer wp.mu.RUnlock()
-
-	if !wp.started {
-		return fmt.Errorf("worker pool is not started")
+	for _, task := range batch.Tasks {
+		if err := wp.Submit(task); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	for _, task := range batch.Tasks {
+// superviseScaling samples queue depth every AutoScale.SampleInterval and
+// scales the worker count between AutoScale.MinWorkers and MaxWorkers:
+// up when the queue stays above ScaleUpThreshold for ScaleUpSampleCount
+// consecutive samples, down when it's been empty for IdleTimeout.
+func (wp *WorkerPool) superviseScaling() {
+	defer wp.wg.Done()
+
+	ticker := time.NewTicker(wp.autoScale.SampleInterval)
+	defer ticker.Stop()
+
+	highSamples := 0
+	var idleSince time.Time
+
+	for {
 		select {
-		case wp.taskQueue <- task:
-			wp.totalTasks++
+		case <-ticker.C:
+			wp.mu.RLock()
+			queueLen := len(wp.taskQueue)
+			queueCap := cap(wp.taskQueue)
+			workerCount := len(wp.workers)
+			wp.mu.RUnlock()
+
+			var load float64
+			if queueCap > 0 {
+				load = float64(queueLen) / float64(queueCap)
+			}
+
+			if load >= wp.autoScale.ScaleUpThreshold && workerCount < wp.autoScale.MaxWorkers {
+				highSamples++
+				if highSamples >= wp.autoScale.ScaleUpSampleCount {
+					wp.scaleUp()
+					highSamples = 0
+				}
+			} else {
+				highSamples = 0
+			}
+
+			if queueLen > 0 {
+				idleSince = time.Time{}
+				continue
+			}
+			if idleSince.IsZero() {
+				idleSince = time.Now()
+			} else if time.Since(idleSince) >= wp.autoScale.IdleTimeout && workerCount > wp.autoScale.MinWorkers {
+				wp.scaleDown()
+				idleSince = time.Now()
+			}
+
 		case <-wp.ctx.Done():
-			return fmt.Errorf("worker pool is shutting down")
-		default:
-			return fmt.Errorf("task queue is full")
+			return
 		}
 	}
+}
 
-	return nil
+// scaleUp spawns one additional worker, unless the pool is already at
+// MaxWorkers.
+func (wp *WorkerPool) scaleUp() {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	if len(wp.workers) >= wp.autoScale.MaxWorkers {
+		return
+	}
+
+	wp.nextWorkerID++
+	worker := &Worker{
+		ID:         wp.nextWorkerID,
+		taskChan:   wp.taskQueue,
+		resultChan: wp.resultQueue,
+		quit:       make(chan bool),
+		wg:         &wp.wg,
+		busy:       &wp.busyWorkers,
+		cancels:    wp,
+	}
+	wp.workers = append(wp.workers, worker)
+	wp.wg.Add(1)
+	go worker.start(wp.ctx)
+
+	atomic.AddInt64(&wp.scaleUpEvents, 1)
+}
+
+// scaleDown signals one worker's quit channel to exit, unless the pool is
+// already at MinWorkers.
+func (wp *WorkerPool) scaleDown() {
+	wp.mu.Lock()
+	if len(wp.workers) <= wp.autoScale.MinWorkers {
+		wp.mu.Unlock()
+		return
+	}
+
+	worker := wp.workers[len(wp.workers)-1]
+	wp.workers = wp.workers[:len(wp.workers)-1]
+	atomic.AddInt64(&wp.scaleDownEvents, 1)
+	wp.mu.Unlock()
+
+	close(worker.quit)
+}
+
+// taskCancel pairs a per-task cancel channel with the timer (if any) that
+// closes it once the task's Deadline/Timeout elapses. Mirrors the deadline
+// timer pattern from netstack's gonet adapter: a Stop() that returns false
+// means the timer already fired, so its channel may already be closed and
+// must be replaced rather than reused.
+type taskCancel struct {
+	ch    chan struct{}
+	timer *time.Timer
+	once  sync.Once
+}
+
+func (tc *taskCancel) close() {
+	tc.once.Do(func() { close(tc.ch) })
+}
+
+// armTask registers taskID's cancel channel and, if task carries a Deadline
+// or Timeout, starts a timer that closes the channel once it elapses.
+// Timeout takes precedence over Deadline if both are set. The channel is
+// returned regardless, so Cancel works even for tasks with neither set.
+func (wp *WorkerPool) armTask(task Task) <-chan struct{} {
+	wp.cancelMu.Lock()
+	defer wp.cancelMu.Unlock()
+
+	tc, ok := wp.cancelChans[task.ID]
+	if ok && tc.timer != nil && !tc.timer.Stop() {
+		tc = nil
+	}
+	if tc == nil {
+		tc = &taskCancel{ch: make(chan struct{})}
+		wp.cancelChans[task.ID] = tc
+	}
+
+	var d time.Duration
+	switch {
+	case task.Timeout > 0:
+		d = task.Timeout
+	case !task.Deadline.IsZero():
+		d = time.Until(task.Deadline)
+	default:
+		return tc.ch
+	}
+
+	tc.timer = time.AfterFunc(d, tc.close)
+	return tc.ch
+}
+
+// releaseTask stops taskID's deadline timer, if any, and forgets its cancel
+// channel once the task has finished, so cancelChans doesn't grow unbounded
+// over the pool's lifetime.
+func (wp *WorkerPool) releaseTask(taskID string) {
+	wp.cancelMu.Lock()
+	defer wp.cancelMu.Unlock()
+
+	tc, ok := wp.cancelChans[taskID]
+	if !ok {
+		return
+	}
+	if tc.timer != nil {
+		tc.timer.Stop()
+	}
+	delete(wp.cancelChans, taskID)
+}
+
+// Cancel revokes taskID's in-flight task immediately, closing its cancel
+// channel without waiting out its deadline or tearing down the rest of the
+// pool. A no-op if taskID isn't currently running.
+func (wp *WorkerPool) Cancel(taskID string) {
+	wp.cancelMu.Lock()
+	tc, ok := wp.cancelChans[taskID]
+	wp.cancelMu.Unlock()
+	if !ok {
+		return
+	}
+	tc.close()
 }
 
 func (wp *WorkerPool) Results() <-chan Result {
@@ -138,14 +356,22 @@ func (wp *WorkerPool) GetStats() WorkerPoolStats {
 	wp.mu.RLock()
 	defer wp.mu.RUnlock()
 
+	activeWorkers := int(atomic.LoadInt64(&wp.busyWorkers))
+	currentWorkers := len(wp.workers)
+
 	return WorkerPoolStats{
-		WorkerCount:    wp.workerCount,
-		TotalTasks:     wp.totalTasks,
-		CompletedTasks: wp.completedTasks,
-		FailedTasks:    wp.failedTasks,
-		QueueLength:    int64(len(wp.taskQueue)),
-		Uptime:         time.Since(wp.startTime),
-		IsRunning:      wp.started,
+		WorkerCount:     wp.workerCount,
+		CurrentWorkers:  currentWorkers,
+		ActiveWorkers:   activeWorkers,
+		IdleWorkers:     currentWorkers - activeWorkers,
+		TotalTasks:      atomic.LoadInt64(&wp.totalTasks),
+		CompletedTasks:  wp.completedTasks,
+		FailedTasks:     wp.failedTasks,
+		QueueLength:     int64(len(wp.taskQueue)),
+		Uptime:          time.Since(wp.startTime),
+		IsRunning:       wp.started,
+		ScaleUpEvents:   atomic.LoadInt64(&wp.scaleUpEvents),
+		ScaleDownEvents: atomic.LoadInt64(&wp.scaleDownEvents),
 	}
 }
 
@@ -0,0 +1,205 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestPool(t *testing.T) *WorkerPool {
+	t.Helper()
+	pool := NewWorkerPool(WorkerPoolConfig{WorkerCount: 2, TaskQueueSize: 10})
+	if err := pool.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(pool.Stop)
+	return pool
+}
+
+func awaitResult(t *testing.T, pool *WorkerPool) Result {
+	t.Helper()
+	select {
+	case res := <-pool.Results():
+		return res
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a task result")
+		return Result{}
+	}
+}
+
+func TestWorkerPool_Timeout(t *testing.T) {
+	pool := newTestPool(t)
+
+	err := pool.Submit(Task{
+		ID:      "timeout-task",
+		Timeout: 20 * time.Millisecond,
+		Execute: func(ctx context.Context, data interface{}) (interface{}, error) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Second):
+				return "too slow", nil
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	res := awaitResult(t, pool)
+	if !errors.Is(res.Error, context.Canceled) {
+		t.Fatalf("Error = %v, want context.Canceled (task's ctx cancelled by Timeout)", res.Error)
+	}
+}
+
+func TestWorkerPool_Deadline(t *testing.T) {
+	pool := newTestPool(t)
+
+	err := pool.Submit(Task{
+		ID:       "deadline-task",
+		Deadline: time.Now().Add(20 * time.Millisecond),
+		Execute: func(ctx context.Context, data interface{}) (interface{}, error) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Second):
+				return "too slow", nil
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	res := awaitResult(t, pool)
+	if !errors.Is(res.Error, context.Canceled) {
+		t.Fatalf("Error = %v, want context.Canceled (task's ctx cancelled by Deadline)", res.Error)
+	}
+}
+
+func TestWorkerPool_TimeoutTakesPrecedenceOverDeadline(t *testing.T) {
+	pool := newTestPool(t)
+
+	started := make(chan struct{})
+	err := pool.Submit(Task{
+		ID:       "both-set",
+		Timeout:  20 * time.Millisecond,
+		Deadline: time.Now().Add(time.Hour),
+		Execute: func(ctx context.Context, data interface{}) (interface{}, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	<-started
+	res := awaitResult(t, pool)
+	if !errors.Is(res.Error, context.Canceled) {
+		t.Fatalf("Error = %v, want context.Canceled (Timeout, not the 1h Deadline, should have fired)", res.Error)
+	}
+}
+
+func TestWorkerPool_Cancel(t *testing.T) {
+	pool := newTestPool(t)
+
+	started := make(chan struct{})
+	err := pool.Submit(Task{
+		ID: "cancel-me",
+		Execute: func(ctx context.Context, data interface{}) (interface{}, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	<-started
+	pool.Cancel("cancel-me")
+
+	res := awaitResult(t, pool)
+	if !errors.Is(res.Error, context.Canceled) {
+		t.Fatalf("Error = %v, want context.Canceled after Cancel", res.Error)
+	}
+}
+
+func TestWorkerPool_CancelUnknownTaskIsNoop(t *testing.T) {
+	pool := newTestPool(t)
+	pool.Cancel("never-submitted")
+}
+
+func TestWorkerPool_NoDeadlineRunsToCompletion(t *testing.T) {
+	pool := newTestPool(t)
+
+	err := pool.Submit(Task{
+		ID: "plain-task",
+		Execute: func(ctx context.Context, data interface{}) (interface{}, error) {
+			return "done", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	res := awaitResult(t, pool)
+	if res.Error != nil {
+		t.Fatalf("Error = %v, want nil", res.Error)
+	}
+	if res.Result != "done" {
+		t.Fatalf("Result = %v, want %q", res.Result, "done")
+	}
+}
+
+// TestWorkerPool_ArmTaskReplacesFiredTimer exercises armTask's documented
+// edge case directly: reusing a Task.ID after its first timer has already
+// fired must hand back a fresh, open channel rather than one that's already
+// closed.
+func TestWorkerPool_ArmTaskReplacesFiredTimer(t *testing.T) {
+	pool := NewWorkerPool(WorkerPoolConfig{WorkerCount: 1})
+
+	first := pool.armTask(Task{ID: "reused", Timeout: time.Millisecond})
+	select {
+	case <-first:
+	case <-time.After(time.Second):
+		t.Fatal("first cancel channel never closed")
+	}
+
+	second := pool.armTask(Task{ID: "reused"})
+	select {
+	case <-second:
+		t.Fatal("second cancel channel for the same task ID was already closed")
+	default:
+	}
+
+	pool.Cancel("reused")
+	select {
+	case <-second:
+	case <-time.After(time.Second):
+		t.Fatal("second cancel channel did not close after Cancel")
+	}
+}
+
+func TestWorkerPool_ReleaseTaskStopsTimer(t *testing.T) {
+	pool := NewWorkerPool(WorkerPoolConfig{WorkerCount: 1})
+
+	ch := pool.armTask(Task{ID: "released", Timeout: time.Hour})
+	pool.releaseTask("released")
+
+	select {
+	case <-ch:
+		t.Fatal("cancel channel closed after releaseTask, want the timer to have been stopped")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	pool.cancelMu.Lock()
+	_, ok := pool.cancelChans["released"]
+	pool.cancelMu.Unlock()
+	if ok {
+		t.Fatal("releaseTask left an entry in cancelChans")
+	}
+}
@@ -10,6 +10,15 @@ type Task struct {
 	Data     interface{}
 	Execute  func(ctx context.Context, data interface{}) (interface{}, error)
 	Callback func(result interface{}, err error)
+
+	// Deadline and Timeout give this task a revocable limit on top of the
+	// pool's own lifetime: whichever elapses first cancels the ctx passed to
+	// Execute, instead of a stuck task pinning its worker until the whole
+	// pool shuts down. Timeout takes precedence if both are set; leave both
+	// zero to rely solely on the pool's context, or call WorkerPool.Cancel
+	// with this Task's ID to revoke it before either fires.
+	Deadline time.Time
+	Timeout  time.Duration
 }
 
 type Result struct {
@@ -25,17 +34,56 @@ type BatchTask struct {
 	Tasks []Task
 }
 
+// AutoScaleConfig lets WorkerPool grow past WorkerCount during a burst and
+// shrink back down once the queue goes idle, instead of running a fixed
+// number of workers for the pool's whole lifetime.
+type AutoScaleConfig struct {
+	Enabled bool
+
+	// MinWorkers/MaxWorkers bound how many workers the pool keeps running.
+	// MinWorkers defaults to WorkerCount; MaxWorkers defaults to 4x that.
+	MinWorkers int
+	MaxWorkers int
+
+	// ScaleUpThreshold is the queue-fullness fraction (len(taskQueue) /
+	// cap(taskQueue)) that must be sustained for ScaleUpSampleCount
+	// consecutive samples before a new worker is spawned. Defaults to 0.75.
+	ScaleUpThreshold   float64
+	ScaleUpSampleCount int
+
+	// SampleInterval is how often the supervisor checks queue depth.
+	// Defaults to 500ms.
+	SampleInterval time.Duration
+
+	// IdleTimeout is how long the queue must stay empty, with more than
+	// MinWorkers running, before a worker is let go. Defaults to 30s.
+	IdleTimeout time.Duration
+}
+
 type WorkerPoolConfig struct {
 	WorkerCount   int
 	TaskQueueSize int
+
+	// SubmitTimeout bounds how long Submit blocks waiting for queue space
+	// before giving up, instead of failing immediately when the queue is
+	// momentarily full. Defaults to 5s; AutoScale needs this room to react.
+	SubmitTimeout time.Duration
+
+	AutoScale AutoScaleConfig
 }
 
 type WorkerPoolStats struct {
 	WorkerCount    int
+	CurrentWorkers int
+	ActiveWorkers  int
+	IdleWorkers    int
 	TotalTasks     int64
 	CompletedTasks int64
 	FailedTasks    int64
 	QueueLength    int64
 	Uptime         time.Duration
 	IsRunning      bool
+
+	ScaleUpEvents   int64
+	ScaleDownEvents int64
 }
@@ -3,15 +3,33 @@ package workerpool
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// taskCanceller is the subset of WorkerPool a Worker needs to arm and
+// release a task's per-task deadline/cancellation, kept narrow so Worker
+// doesn't depend on the rest of WorkerPool.
+type taskCanceller interface {
+	armTask(task Task) <-chan struct{}
+	releaseTask(taskID string)
+}
+
 type Worker struct {
 	ID         int
 	taskChan   chan Task
 	resultChan chan Result
 	quit       chan bool
 	wg         *sync.WaitGroup
+
+	// busy is the pool's shared ActiveWorkers counter; this worker adds to
+	// it while running a task so WorkerPool.GetStats can tell active
+	// workers apart from ones idling on taskChan.
+	busy *int64
+
+	// cancels arms and releases each task's deadline/cancel channel; always
+	// the owning WorkerPool.
+	cancels taskCanceller
 }
 
 func (w *Worker) start(ctx context.Context) {
@@ -25,6 +43,9 @@ func (w *Worker) start(ctx context.Context) {
 			}
 			w.executeTask(ctx, task)
 
+		case <-w.quit:
+			return
+
 		case <-ctx.Done():
 			return
 		}
@@ -32,10 +53,27 @@ func (w *Worker) start(ctx context.Context) {
 }
 
 func (w *Worker) executeTask(ctx context.Context, task Task) {
+	atomic.AddInt64(w.busy, 1)
+	defer atomic.AddInt64(w.busy, -1)
+
 	startTime := time.Now()
 	taskCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	cancelChan := w.cancels.armTask(task)
+	defer w.cancels.releaseTask(task.ID)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-cancelChan:
+			cancel()
+		case <-ctx.Done():
+		case <-done:
+		}
+	}()
+
 	result, err := task.Execute(taskCtx, task.Data)
 
 	endTime := time.Now()